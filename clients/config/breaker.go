@@ -0,0 +1,107 @@
+package config
+
+import (
+	"sync"
+	"time"
+)
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+const (
+	defaultFailureThreshold = 5
+	defaultOpenTimeout      = 30 * time.Second
+)
+
+// CircuitBreaker opens after consecutive failures and lets a single probe
+// through once the open timeout has elapsed.
+type CircuitBreaker struct {
+	mutex            sync.Mutex
+	failureThreshold int
+	openTimeout      time.Duration
+	failures         int
+	state            breakerState
+	openedAt         time.Time
+	probing          bool
+}
+
+type ICircuitBreaker interface {
+	Allow() bool
+	Success()
+	Failure()
+	Release()
+}
+
+func NewCircuitBreaker(failureThreshold int, openTimeout time.Duration) ICircuitBreaker {
+	if failureThreshold <= 0 {
+		failureThreshold = defaultFailureThreshold
+	}
+
+	if openTimeout <= 0 {
+		openTimeout = defaultOpenTimeout
+	}
+
+	return &CircuitBreaker{
+		failureThreshold: failureThreshold,
+		openTimeout:      openTimeout,
+	}
+}
+
+func (b *CircuitBreaker) Allow() bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if time.Since(b.openedAt) < b.openTimeout {
+			return false
+		}
+		b.state = breakerHalfOpen
+		b.probing = true
+		return true
+	case breakerHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+func (b *CircuitBreaker) Success() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.failures = 0
+	b.probing = false
+	b.state = breakerClosed
+}
+
+func (b *CircuitBreaker) Failure() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.failures++
+	b.probing = false
+	if b.state == breakerHalfOpen || b.failures >= b.failureThreshold {
+		b.state = breakerOpen
+		b.openedAt = time.Now()
+	}
+}
+
+// Release ends a call that got no answer from upstream through no fault of its
+// own, such as one cancelled by the caller. It frees the half-open probe slot
+// without counting a failure.
+func (b *CircuitBreaker) Release() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.probing = false
+}
@@ -1,17 +1,34 @@
 package config
 
-import "github.com/parnurzeal/gorequest"
+import (
+	"time"
+
+	"github.com/parnurzeal/gorequest"
+)
+
+const (
+	defaultTimeout      = 5 * time.Second
+	defaultRetryBackoff = 100 * time.Millisecond
+)
 
 type ClientConfig struct {
-	client       *gorequest.SuperAgent
-	baseURL      string
-	signatureKey string
+	client         *gorequest.SuperAgent
+	baseURL        string
+	signatureKey   string
+	timeout        time.Duration
+	maxRetries     int
+	retryBackoff   time.Duration
+	circuitBreaker ICircuitBreaker
 }
 
 type IClientConfig interface {
 	Client() *gorequest.SuperAgent
 	BaseURL() string
 	SignatureKey() string
+	Timeout() time.Duration
+	MaxRetries() int
+	RetryBackoff() time.Duration
+	CircuitBreaker() ICircuitBreaker
 }
 
 type Option func(*ClientConfig)
@@ -21,12 +38,21 @@ func NewClientConfig(options ...Option) IClientConfig {
 		client: gorequest.New().
 			Set("Content-Type", "application/json").
 			Set("Accept", "application/json"),
+		timeout:      defaultTimeout,
+		retryBackoff: defaultRetryBackoff,
 	}
 
 	for _, option := range options {
 		option(clientConfig)
 	}
 
+	// gorequest recommends fixing the timeout on the base agent before cloning.
+	clientConfig.client.Timeout(clientConfig.timeout)
+
+	if clientConfig.circuitBreaker == nil {
+		clientConfig.circuitBreaker = NewCircuitBreaker(0, 0)
+	}
+
 	return clientConfig
 }
 
@@ -42,6 +68,22 @@ func (c *ClientConfig) SignatureKey() string {
 	return c.signatureKey
 }
 
+func (c *ClientConfig) Timeout() time.Duration {
+	return c.timeout
+}
+
+func (c *ClientConfig) MaxRetries() int {
+	return c.maxRetries
+}
+
+func (c *ClientConfig) RetryBackoff() time.Duration {
+	return c.retryBackoff
+}
+
+func (c *ClientConfig) CircuitBreaker() ICircuitBreaker {
+	return c.circuitBreaker
+}
+
 func WithBaseURL(baseURL string) Option {
 	return func(c *ClientConfig) {
 		c.baseURL = baseURL
@@ -53,3 +95,29 @@ func WithSignatureKey(signatureKey string) Option {
 		c.signatureKey = signatureKey
 	}
 }
+
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *ClientConfig) {
+		if timeout > 0 {
+			c.timeout = timeout
+		}
+	}
+}
+
+func WithRetry(maxRetries int, backoff time.Duration) Option {
+	return func(c *ClientConfig) {
+		if maxRetries > 0 {
+			c.maxRetries = maxRetries
+		}
+
+		if backoff > 0 {
+			c.retryBackoff = backoff
+		}
+	}
+}
+
+func WithCircuitBreaker(failureThreshold int, openTimeout time.Duration) Option {
+	return func(c *ClientConfig) {
+		c.circuitBreaker = NewCircuitBreaker(failureThreshold, openTimeout)
+	}
+}
@@ -4,23 +4,43 @@ import (
 	"field-service/clients/config"
 	clients "field-service/clients/users"
 	config2 "field-service/config"
+	"time"
 )
 
 type ClientRegistry struct {
+	user clients.ICachedUserClient
 }
 
 type IClientRegistry interface {
 	GetUser() clients.IUserClient
+	GetUserCache() clients.ICachedUserClient
 }
 
 func NewClientRegistry() IClientRegistry {
-	return &ClientRegistry{}
+	user := config2.Config.InternalService.User
+	userClient := clients.NewUserClient(config.
+		NewClientConfig(
+			config.WithBaseURL(user.Host),
+			config.WithSignatureKey(user.SignatureKey),
+			config.WithTimeout(time.Duration(user.TimeoutSecond)*time.Second),
+			config.WithRetry(user.MaxRetries, time.Duration(user.RetryBackoffMillisecond)*time.Millisecond),
+			config.WithCircuitBreaker(user.CircuitBreakerThreshold, time.Duration(user.CircuitBreakerTimeoutSecond)*time.Second),
+		))
+
+	return &ClientRegistry{
+		user: clients.NewCachedUserClient(
+			userClient,
+			time.Duration(user.CacheTTLSecond)*time.Second,
+			user.CacheMaxSize,
+			time.Duration(user.TimeoutSecond*(user.MaxRetries+1))*time.Second,
+		),
+	}
 }
 
 func (c *ClientRegistry) GetUser() clients.IUserClient {
-	return clients.NewUserClient(config.
-		NewClientConfig(
-			config.WithBaseURL(config2.Config.InternalService.User.Host),
-			config.WithSignatureKey(config2.Config.InternalService.User.SignatureKey),
-		))
+	return c.user
+}
+
+func (c *ClientRegistry) GetUserCache() clients.ICachedUserClient {
+	return c.user
 }
@@ -0,0 +1,210 @@
+package clients
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"field-service/common/util"
+	"field-service/constants"
+	errConstants "field-service/constants/error"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	defaultCacheTTL     = 60 * time.Second
+	defaultCacheMaxSize = 1000
+	defaultFetchTimeout = 10 * time.Second
+)
+
+type CachedUserClient struct {
+	client       IUserClient
+	ttl          time.Duration
+	maxSize      int
+	fetchTimeout time.Duration
+	mutex        sync.Mutex
+	entries      map[string]*list.Element
+	order        *list.List
+	group        singleflight.Group
+	hits         atomic.Uint64
+	misses       atomic.Uint64
+}
+
+type ICachedUserClient interface {
+	IUserClient
+	Invalidate(string)
+	InvalidateAll()
+	Stats() CacheStats
+}
+
+type CacheStats struct {
+	Hits   uint64 `json:"hits"`
+	Misses uint64 `json:"misses"`
+	Size   int    `json:"size"`
+}
+
+type cacheEntry struct {
+	key       string
+	user      *UserData
+	expiresAt time.Time
+}
+
+// NewCachedUserClient caches users by token. fetchTimeout bounds a lookup
+// shared by concurrent callers, since it no longer follows any one of them.
+func NewCachedUserClient(client IUserClient, ttl time.Duration, maxSize int, fetchTimeout time.Duration) ICachedUserClient {
+	if ttl <= 0 {
+		ttl = defaultCacheTTL
+	}
+
+	if maxSize <= 0 {
+		maxSize = defaultCacheMaxSize
+	}
+
+	if fetchTimeout <= 0 {
+		fetchTimeout = defaultFetchTimeout
+	}
+
+	return &CachedUserClient{
+		client:       client,
+		ttl:          ttl,
+		maxSize:      maxSize,
+		fetchTimeout: fetchTimeout,
+		entries:      make(map[string]*list.Element),
+		order:        list.New(),
+	}
+}
+
+func tokenFromContext(ctx context.Context) string {
+	token, _ := ctx.Value(constants.Token).(string)
+	return token
+}
+
+func (c *CachedUserClient) GetUserByToken(ctx context.Context) (*UserData, error) {
+	token := tokenFromContext(ctx)
+	if token == "" {
+		return c.client.GetUserByToken(ctx)
+	}
+
+	key := util.GenerateSHA256(token)
+	user, ok := c.get(key)
+	if ok {
+		c.hits.Add(1)
+		return user, nil
+	}
+
+	c.misses.Add(1)
+	result := c.group.DoChan(key, func() (any, error) {
+		// Callers that join the lookup must not fail because the first one went away.
+		fetchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), c.fetchTimeout)
+		defer cancel()
+
+		user, err := c.client.GetUserByToken(fetchCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		c.set(key, user)
+		return user, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		// A caller that hung up says nothing about the user service; one that ran
+		// out of time waited on it for too long.
+		if errors.Is(ctx.Err(), context.Canceled) {
+			return nil, ctx.Err()
+		}
+		return nil, errConstants.ErrAuthUpstreamUnavailable
+	case shared := <-result:
+		if shared.Err != nil {
+			return nil, shared.Err
+		}
+
+		return shared.Val.(*UserData), nil
+	}
+}
+
+func (c *CachedUserClient) Ping(ctx context.Context) error {
+	return c.client.Ping(ctx)
+}
+
+func (c *CachedUserClient) get(key string) (*UserData, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := element.Value.(*cacheEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.removeElement(element)
+		return nil, false
+	}
+
+	c.order.MoveToFront(element)
+	return entry.user, true
+}
+
+func (c *CachedUserClient) set(key string, user *UserData) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	expiresAt := time.Now().Add(c.ttl)
+	if element, ok := c.entries[key]; ok {
+		entry := element.Value.(*cacheEntry)
+		entry.user = user
+		entry.expiresAt = expiresAt
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&cacheEntry{
+		key:       key,
+		user:      user,
+		expiresAt: expiresAt,
+	})
+
+	for c.order.Len() > c.maxSize {
+		c.removeElement(c.order.Back())
+	}
+}
+
+func (c *CachedUserClient) removeElement(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*cacheEntry).key)
+}
+
+func (c *CachedUserClient) Invalidate(token string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, ok := c.entries[util.GenerateSHA256(token)]
+	if ok {
+		c.removeElement(element)
+	}
+}
+
+func (c *CachedUserClient) InvalidateAll() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.entries = make(map[string]*list.Element)
+	c.order.Init()
+}
+
+func (c *CachedUserClient) Stats() CacheStats {
+	c.mutex.Lock()
+	size := c.order.Len()
+	c.mutex.Unlock()
+
+	return CacheStats{
+		Hits:   c.hits.Load(),
+		Misses: c.misses.Load(),
+		Size:   size,
+	}
+}
@@ -2,13 +2,24 @@ package clients
 
 import (
 	"context"
+	"errors"
 	"field-service/clients/config"
+	"field-service/common/logger"
+	"field-service/common/metrics"
+	"field-service/common/tracing"
 	"field-service/common/util"
 	config2 "field-service/config"
 	"field-service/constants"
+	errConstants "field-service/constants/error"
 	"fmt"
 	"net/http"
 	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type UserClient struct {
@@ -17,6 +28,7 @@ type UserClient struct {
 
 type IUserClient interface {
 	GetUserByToken(context.Context) (*UserData, error)
+	Ping(context.Context) error
 }
 
 func NewUserClient(client config.IClientConfig) IUserClient {
@@ -26,6 +38,80 @@ func NewUserClient(client config.IClientConfig) IUserClient {
 }
 
 func (u *UserClient) GetUserByToken(ctx context.Context) (*UserData, error) {
+	token, ok := ctx.Value(constants.Token).(string)
+	if !ok || token == "" {
+		return nil, errConstants.ErrUnauthorized
+	}
+
+	breaker := u.client.CircuitBreaker()
+	if !breaker.Allow() {
+		metrics.UserClientErrorsTotal.WithLabelValues("circuit_open").Inc()
+		return nil, errConstants.ErrAuthUpstreamUnavailable
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= u.client.MaxRetries(); attempt++ {
+		if attempt > 0 {
+			backoff := u.client.RetryBackoff() * time.Duration(1<<(attempt-1))
+			select {
+			case <-ctx.Done():
+				// A caller that went away says nothing about the user service.
+				if errors.Is(ctx.Err(), context.Canceled) {
+					breaker.Release()
+					return nil, ctx.Err()
+				}
+				breaker.Failure()
+				return nil, errConstants.ErrAuthUpstreamUnavailable
+			case <-time.After(backoff):
+			}
+		}
+
+		start := time.Now()
+		user, retryable, err := u.getUserByToken(ctx, token)
+		u.observe(start, retryable, err)
+		if err == nil || !retryable {
+			breaker.Success()
+			return user, err
+		}
+
+		lastErr = err
+		logger.FromContext(ctx).Warnf("user service request failed (attempt %d): %v", attempt+1, err)
+	}
+
+	if errors.Is(lastErr, context.Canceled) {
+		breaker.Release()
+		return nil, context.Canceled
+	}
+	breaker.Failure()
+	logger.FromContext(ctx).Errorf("user service unavailable: %v", lastErr)
+	return nil, errConstants.ErrAuthUpstreamUnavailable
+}
+
+func (u *UserClient) observe(start time.Time, retryable bool, err error) {
+	if err == nil {
+		metrics.UserClientRequestDuration.WithLabelValues(metrics.OutcomeSuccess).Observe(time.Since(start).Seconds())
+		return
+	}
+
+	metrics.UserClientRequestDuration.WithLabelValues(metrics.OutcomeError).Observe(time.Since(start).Seconds())
+	if retryable {
+		metrics.UserClientErrorsTotal.WithLabelValues("upstream").Inc()
+	} else {
+		metrics.UserClientErrorsTotal.WithLabelValues("rejected").Inc()
+	}
+}
+
+// getUserByToken performs a single request and reports whether a failure is worth retrying.
+func (u *UserClient) getUserByToken(ctx context.Context, token string) (user *UserData, retryable bool, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "UserClient.GetUserByToken", trace.WithSpanKind(trace.SpanKindClient))
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
 	unixTime := time.Now().Unix()
 	generateAPIKey := fmt.Sprintf("%s:%s:%d",
 		config2.Config.AppName,
@@ -34,7 +120,6 @@ func (u *UserClient) GetUserByToken(ctx context.Context) (*UserData, error) {
 	)
 
 	apiKey := util.GenerateSHA256(generateAPIKey)
-	token := ctx.Value("token").(string)
 	bearerToken := fmt.Sprintf("Bearer %s", token)
 
 	var response UserResponse
@@ -45,16 +130,53 @@ func (u *UserClient) GetUserByToken(ctx context.Context) (*UserData, error) {
 		Set(constants.XRequestAt, fmt.Sprintf("%d", unixTime)).
 		Get(fmt.Sprintf("%s/api/v1/auth/user", u.client.BaseURL()))
 
+	carrier := propagation.HeaderCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	for key := range carrier {
+		request.Set(key, carrier.Get(key))
+	}
+
 	resps, _, errs := request.EndStruct(&response)
 
 	if len(errs) > 0 {
-		return nil, errs[0]
+		if resps != nil && resps.StatusCode < http.StatusInternalServerError {
+			return nil, false, errs[0]
+		}
+		return nil, true, errs[0]
+	}
+
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resps.StatusCode))
+	if resps.StatusCode >= http.StatusInternalServerError {
+		return nil, true, fmt.Errorf("user response status %d", resps.StatusCode)
 	}
 
 	if resps.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("user response : %s", response.Message)
+		return nil, false, fmt.Errorf("user response : %s", response.Message)
 	}
 
-	return &response.Data, nil
+	return &response.Data, false, nil
+}
+
+// Ping reports whether the user service answers at all; any non-5xx response counts.
+func (u *UserClient) Ping(ctx context.Context) error {
+	ctx, span := tracing.Tracer().Start(ctx, "UserClient.Ping", trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, u.client.BaseURL(), nil)
+	if err != nil {
+		return err
+	}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(request.Header))
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("user service responded with status %d", response.StatusCode)
+	}
 
+	return nil
 }
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"context"
+	"field-service/common/broker"
+	"field-service/config"
+	orderConsumer "field-service/consumers/order"
+	"field-service/repositories"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var orderConsumerCommand = &cobra.Command{
+	Use:   "order-consumer",
+	Short: "Consume order events and update field schedule status",
+	Run: func(c *cobra.Command, args []string) {
+		_ = godotenv.Load()
+		config.Init()
+		initLogger()
+		flushTraces := initTracing()
+		defer flushTraces()
+		db, err := config.InitDatabase()
+		if err != nil {
+			panic(err)
+		}
+		defer closeDatabase(db)
+
+		loc, err := time.LoadLocation("Asia/Jakarta")
+		if err != nil {
+			panic(err)
+		}
+
+		time.Local = loc
+
+		subscriber := initBroker()
+		defer subscriber.Close()
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		repository := repositories.NewRepositoryRegistry(db)
+		consumer := newOrderConsumer(repository, subscriber)
+		err = consumer.Start(ctx)
+		if err != nil {
+			panic(err)
+		}
+
+		logrus.Infof("order consumer started with %s broker", config.Config.Broker.Type)
+		<-ctx.Done()
+		consumer.Wait()
+		logrus.Info("order consumer stopped")
+	},
+}
+
+var orderConsumerReplayCommand = &cobra.Command{
+	Use:   "replay",
+	Short: "Process dead-lettered order events again",
+	Args:  cobra.NoArgs,
+	RunE: func(c *cobra.Command, args []string) error {
+		limit, err := c.Flags().GetInt("limit")
+		if err != nil {
+			return err
+		}
+		if limit < 1 {
+			return fmt.Errorf("limit must be at least 1")
+		}
+
+		_ = godotenv.Load()
+		config.Init()
+		initLogger()
+		db, err := config.InitDatabase()
+		if err != nil {
+			return err
+		}
+		defer closeDatabase(db)
+
+		loc, err := time.LoadLocation("Asia/Jakarta")
+		if err != nil {
+			return err
+		}
+
+		time.Local = loc
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		repository := repositories.NewRepositoryRegistry(db)
+		replayed, err := newOrderConsumer(repository, nil).Replay(ctx, limit)
+		logrus.Infof("replayed %d dead-lettered order events", replayed)
+		return err
+	},
+}
+
+func init() {
+	orderConsumerReplayCommand.Flags().Int("limit", 100, "maximum number of dead letters to replay")
+	orderConsumerCommand.AddCommand(orderConsumerReplayCommand)
+}
+
+func newOrderConsumer(
+	repository repositories.IRepostitoryRegistry,
+	subscriber broker.ISubscriber,
+) orderConsumer.IOrderConsumer {
+	return orderConsumer.NewOrderConsumer(repository, subscriber, orderConsumer.OrderConsumerConfig{
+		MaxAttempts:  config.Config.OrderConsumer.MaxAttempts,
+		RetryBackoff: time.Duration(config.Config.OrderConsumer.RetryBackoffMillisecond) * time.Millisecond,
+	})
+}
@@ -1,28 +1,44 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"field-service/clients"
+	"field-service/common/broker"
+	"field-service/common/cursor"
 	gcs "field-service/common/gcs"
+	"field-service/common/health"
+	"field-service/common/metrics"
+	"field-service/common/rbac"
 	"field-service/common/response"
 	"field-service/config"
 	"field-service/constants"
 	"field-service/controllers"
 	"field-service/domain/models"
+	"field-service/grpcserver"
 	"field-service/middlewares"
 	"field-service/repositories"
 	"field-service/routes"
 	"field-service/services"
+	webhookService "field-service/services/webhook"
 	"fmt"
+	"net"
 	"net/http"
+	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/didip/tollbooth"
 	"github.com/didip/tollbooth/limiter"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"gorm.io/gorm"
 )
 
 var command = &cobra.Command{
@@ -31,6 +47,10 @@ var command = &cobra.Command{
 	Run: func(c *cobra.Command, args []string) {
 		_ = godotenv.Load()
 		config.Init()
+		initLogger()
+		rbac.Init(config.Config.PolicyFile)
+		cursor.Init(config.Config.Pagination.CursorSecret)
+		flushTraces := initTracing()
 		db, err := config.InitDatabase()
 		if err != nil {
 			panic(err)
@@ -43,13 +63,31 @@ var command = &cobra.Command{
 
 		time.Local = loc
 
-		err = db.AutoMigrate(
-			&models.Field{},
-			&models.FieldSchedule{},
-			&models.Time{},
-		)
-		if err != nil {
-			panic(err)
+		if config.Config.Migration.ShouldRunOnStart() {
+			migrateOnStart(db)
+		} else if !config.Config.Migration.AutoMigrate {
+			checkSchemaVersion(db)
+		}
+
+		// AutoMigrate is kept for local development only; it cannot express the
+		// constraints and data changes that the versioned migrations carry.
+		if config.Config.Migration.AutoMigrate {
+			err = db.AutoMigrate(
+				&models.Field{},
+				&models.FieldSchedule{},
+				&models.Time{},
+				&models.AuditLog{},
+				&models.WebhookSubscription{},
+				&models.WebhookDelivery{},
+				&models.OutboxEvent{},
+				&models.ProcessedMessage{},
+				&models.DeadLetterMessage{},
+				&models.IdempotencyKey{},
+				&models.ReplayKey{},
+			)
+			if err != nil {
+				panic(err)
+			}
 		}
 
 		gcs := initGCS()
@@ -58,7 +96,51 @@ var command = &cobra.Command{
 		service := services.NewServiceRegistry(repository, gcs)
 		controller := controllers.NewControllerRegistry(service)
 
-		router := gin.Default()
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		background := &workers{}
+		closers := []func() error{flushTraces}
+		dispatcher := webhookService.NewDispatcher(repository, webhookService.DispatcherConfig{
+			MaxAttempts:  config.Config.Webhook.MaxAttempts,
+			RetryBase:    time.Duration(config.Config.Webhook.RetryBaseSecond) * time.Second,
+			PollInterval: time.Duration(config.Config.Webhook.PollIntervalSecond) * time.Second,
+			Timeout:      time.Duration(config.Config.Webhook.TimeoutSecond) * time.Second,
+			BatchSize:    config.Config.Webhook.BatchSize,
+		})
+		background.Go("webhook dispatcher", func() { dispatcher.Run(ctx) })
+
+		if config.Config.Outbox.RunInProcess || config.Config.OrderConsumer.RunInProcess {
+			eventBroker := initBroker()
+			closers = append(closers, eventBroker.Close)
+			if config.Config.Outbox.RunInProcess {
+				relay := newOutboxRelay(repository, eventBroker)
+				background.Go("outbox relay", func() { relay.Run(ctx) })
+			}
+
+			if config.Config.OrderConsumer.RunInProcess {
+				consumer := newOrderConsumer(repository, eventBroker)
+				err = consumer.Start(ctx)
+				if err != nil {
+					panic(err)
+				}
+				background.Go("order consumer", func() {
+					<-ctx.Done()
+					consumer.Wait()
+				})
+			}
+		}
+
+		initMetrics(db, repository, client)
+
+		router := gin.New()
+		// Let services read the span and deadline that middlewares put on the request context.
+		router.ContextWithFallback = true
+		router.Use(middlewares.RequestContext())
+		router.Use(middlewares.Tracing())
+		router.Use(middlewares.RequestLogger())
+		router.Use(middlewares.Metrics())
+		router.Use(middlewares.CORS())
 		router.Use(middlewares.HandlePanic())
 		router.NoRoute(func(c *gin.Context) {
 			c.JSON(http.StatusNotFound, response.Response{
@@ -74,34 +156,61 @@ var command = &cobra.Command{
 			})
 		})
 
-		router.Use(func(c *gin.Context) {
-			c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
-			c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, x-service-name, x-api-key, x-request-at")
-			c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE")
-			if c.Request.Method == "OPTIONS" {
-				c.AbortWithStatus(204)
-				return
-			}
-			c.Next()
-		})
+		checker := initHealthChecker(db, gcs, client)
+		router.GET("/healthz", checker.Liveness)
+		router.GET("/readyz", checker.Readiness)
+		router.GET("/metrics", gin.WrapH(promhttp.Handler()))
 
 		lmt := tollbooth.NewLimiter(config.Config.RateLimiterMaxRequest, &limiter.ExpirableOptions{
 			DefaultExpirationTTL: time.Duration(config.Config.RateLimiterTimeSecond) * time.Second,
 		})
 
 		router.Use(middlewares.RateLimiter(lmt))
+		middlewares.InitIdempotency(
+			repository.GetIdempotencyRepository(),
+			time.Duration(config.Config.Idempotency.TTLSecond)*time.Second,
+			time.Duration(config.Config.Idempotency.LockSecond)*time.Second,
+		)
+		background.Go("idempotency cleanup", func() {
+			cleanupExpired(ctx, "idempotency keys", config.Config.Idempotency.CleanupIntervalSecond,
+				repository.GetIdempotencyRepository().DeleteExpired)
+		})
+		middlewares.InitSignatureReplayStore(repository.GetReplayKeyRepository())
+		background.Go("replay key cleanup", func() {
+			cleanupExpired(ctx, "replay keys", config.Config.SignatureReplay.CleanupIntervalSecond,
+				repository.GetReplayKeyRepository().DeleteExpired)
+		})
 
 		group := router.Group("/api/v1")
 		route := routes.NewRouteRegistry(controller, group, client)
 		route.Serve()
 
-		port := fmt.Sprintf(":%d", config.Config.Port)
-		router.Run(port)
+		var grpcServer *grpc.Server
+		if config.Config.GRPCPort > 0 {
+			grpcServer = serveGRPC(service, stop)
+		}
+
+		httpServer := newHTTPServer(router)
+		go func() {
+			logrus.Infof("HTTP server listening on %s", httpServer.Addr)
+			err := httpServer.ListenAndServe()
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logrus.Errorf("HTTP server stopped: %v", err)
+				stop()
+			}
+		}()
 
+		<-ctx.Done()
+		stop()
+		shutdown(httpServer, grpcServer, background, closers, db)
 	},
 }
 
 func Run() {
+	command.AddCommand(outboxRelayCommand)
+	command.AddCommand(orderConsumerCommand)
+	command.AddCommand(migrateCommand)
+	command.AddCommand(purgeCommand)
 	err := command.Execute()
 	if err != nil {
 		panic(err)
@@ -110,7 +219,6 @@ func Run() {
 
 func initGCS() gcs.IGCSClient {
 	stringPrivateKey := strings.ReplaceAll(config.Config.GCSPrivateKey, `\n`, "\n")
-	logrus.Infof("GCS Private Key: %s", stringPrivateKey)
 	gcsServiceAccount := gcs.ServiceAccountKeyJSON{
 		Type:                    config.Config.GCSType,
 		ProjectID:               config.Config.GCSProjectID,
@@ -128,3 +236,114 @@ func initGCS() gcs.IGCSClient {
 	gcsClient := gcs.NewGCSClient(gcsServiceAccount, config.Config.GCSBucketName)
 	return gcsClient
 }
+
+func initHealthChecker(db *gorm.DB, storage gcs.IGCSClient, client clients.IClientRegistry) health.IChecker {
+	millisecond := func(value int) time.Duration {
+		return time.Duration(value) * time.Millisecond
+	}
+
+	healthConfig := config.Config.Health
+	checks := []health.Check{
+		health.DatabaseCheck(db, millisecond(healthConfig.DatabaseTimeoutMillisecond)),
+	}
+
+	if healthConfig.Storage.Enabled {
+		checks = append(checks, health.PingCheck(
+			"storage",
+			healthConfig.Storage.Critical,
+			millisecond(healthConfig.Storage.TimeoutMillisecond),
+			storage.Ping,
+		))
+	}
+
+	if healthConfig.UserService.Enabled {
+		checks = append(checks, health.PingCheck(
+			"userService",
+			healthConfig.UserService.Critical,
+			millisecond(healthConfig.UserService.TimeoutMillisecond),
+			client.GetUser().Ping,
+		))
+	}
+
+	return health.NewChecker(checks...)
+}
+
+func initMetrics(db *gorm.DB, repository repositories.IRepostitoryRegistry, client clients.IClientRegistry) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		panic(err)
+	}
+
+	metrics.RegisterDatabase(sqlDB)
+	metrics.RegisterUserCache(func() (uint64, uint64, int) {
+		stats := client.GetUserCache().Stats()
+		return stats.Hits, stats.Misses, stats.Size
+	})
+	metrics.RegisterAvailableSlots(repository.GetFieldScheduleRepository().CountAvailableByField)
+}
+
+// cleanupExpired runs deleteExpired every intervalSecond, or hourly when unset.
+func cleanupExpired(ctx context.Context, name string, intervalSecond int, deleteExpired func(context.Context) (int64, error)) {
+	interval := time.Duration(intervalSecond) * time.Second
+	if interval <= 0 {
+		interval = time.Hour
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := deleteExpired(ctx)
+			if err != nil {
+				logrus.Errorf("failed to delete expired %s: %v", name, err)
+				continue
+			}
+
+			if deleted > 0 {
+				logrus.Infof("deleted %d expired %s", deleted, name)
+			}
+		}
+	}
+}
+
+func serveGRPC(service services.IServiceRegistry, stop func()) *grpc.Server {
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", config.Config.GRPCPort))
+	if err != nil {
+		panic(err)
+	}
+
+	server := grpcserver.NewServer(service)
+	go func() {
+		logrus.Infof("gRPC server listening on %s", listener.Addr())
+		err := server.Serve(listener)
+		if err != nil {
+			logrus.Errorf("gRPC server stopped: %v", err)
+			stop()
+		}
+	}()
+
+	return server
+}
+
+func initBroker() broker.IBroker {
+	switch config.Config.Broker.Type {
+	case constants.BrokerNATS:
+		natsBroker, err := broker.NewNATSBroker(
+			config.Config.Broker.NATSURL,
+			config.Config.Broker.SubjectPrefix,
+			config.Config.Broker.QueueGroup,
+		)
+		if err != nil {
+			panic(err)
+		}
+		return natsBroker
+	case constants.BrokerMemory:
+		return broker.NewMemoryBroker()
+	default:
+		return broker.NewLogBroker()
+	}
+}
@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"context"
+	"field-service/common/migration"
+	"field-service/config"
+	"field-service/migrations"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+)
+
+var migrateCommand = &cobra.Command{
+	Use:   "migrate",
+	Short: "Manage database schema migrations",
+}
+
+var migrateUpCommand = &cobra.Command{
+	Use:   "up",
+	Short: "Apply all pending migrations",
+	Args:  cobra.NoArgs,
+	RunE: func(c *cobra.Command, args []string) error {
+		return withMigrator(func(ctx context.Context, migrator migration.IMigrator) error {
+			applied, err := migrator.Up(ctx)
+			for _, item := range applied {
+				logrus.Infof("applied migration %06d_%s", item.Version, item.Name)
+			}
+			if err == nil && len(applied) == 0 {
+				logrus.Info("no pending migrations")
+			}
+			return err
+		})
+	},
+}
+
+var migrateDownCommand = &cobra.Command{
+	Use:   "down",
+	Short: "Roll back the most recently applied migrations",
+	Args:  cobra.NoArgs,
+	RunE: func(c *cobra.Command, args []string) error {
+		steps, err := c.Flags().GetInt("steps")
+		if err != nil {
+			return err
+		}
+		if steps < 1 {
+			return fmt.Errorf("steps must be at least 1")
+		}
+
+		return withMigrator(func(ctx context.Context, migrator migration.IMigrator) error {
+			reverted, err := migrator.Down(ctx, steps)
+			for _, item := range reverted {
+				logrus.Infof("rolled back migration %06d_%s", item.Version, item.Name)
+			}
+			return err
+		})
+	},
+}
+
+var migrateStatusCommand = &cobra.Command{
+	Use:   "status",
+	Short: "Show which migrations have been applied",
+	Args:  cobra.NoArgs,
+	RunE: func(c *cobra.Command, args []string) error {
+		return withMigrator(func(ctx context.Context, migrator migration.IMigrator) error {
+			statuses, err := migrator.Status(ctx)
+			if err != nil {
+				return err
+			}
+
+			for _, status := range statuses {
+				state := "pending"
+				if status.AppliedAt != nil {
+					state = fmt.Sprintf("applied %s", status.AppliedAt.Format(time.RFC3339))
+				}
+				if status.Missing {
+					state += " (file missing)"
+				}
+				fmt.Fprintf(c.OutOrStdout(), "%06d_%s\t%s\n", status.Version, status.Name, state)
+			}
+			return nil
+		})
+	},
+}
+
+var migrateCreateCommand = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create an empty up/down migration pair",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(c *cobra.Command, args []string) error {
+		dir, err := c.Flags().GetString("dir")
+		if err != nil {
+			return err
+		}
+
+		paths, err := migration.Create(dir, args[0])
+		if err != nil {
+			return err
+		}
+
+		for _, path := range paths {
+			fmt.Fprintln(c.OutOrStdout(), path)
+		}
+		return nil
+	},
+}
+
+func init() {
+	migrateDownCommand.Flags().Int("steps", 1, "number of migrations to roll back")
+	migrateCreateCommand.Flags().String("dir", migrations.Dir, "directory to write the migration files to")
+	migrateCommand.AddCommand(migrateUpCommand, migrateDownCommand, migrateStatusCommand, migrateCreateCommand)
+}
+
+func withMigrator(fn func(context.Context, migration.IMigrator) error) error {
+	_ = godotenv.Load()
+	config.Init()
+	initLogger()
+	db, err := config.InitDatabase()
+	if err != nil {
+		return err
+	}
+	defer closeDatabase(db)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	migrator, err := newMigrator(db)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := migrationContext(ctx)
+	defer cancel()
+	return fn(ctx, migrator)
+}
+
+func newMigrator(db *gorm.DB) (migration.IMigrator, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	return migration.NewMigrator(sqlDB, migrations.Files, config.Config.AppName)
+}
+
+// migrationContext bounds the wait for the advisory lock when another instance is migrating.
+func migrationContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	timeout := time.Duration(config.Config.Migration.LockTimeoutSecond) * time.Second
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// migrateOnStart brings the schema up to date before serving.
+// checkSchemaVersion refuses to start when migrations are left to run outside
+// the service and some embedded migration has not been applied yet.
+func checkSchemaVersion(db *gorm.DB) {
+	migrator, err := newMigrator(db)
+	if err != nil {
+		panic(err)
+	}
+
+	ctx, cancel := migrationContext(context.Background())
+	defer cancel()
+
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		panic(err)
+	}
+	for _, item := range statuses {
+		if item.AppliedAt == nil && !item.Missing {
+			panic(fmt.Sprintf("migration %06d_%s has not been applied; run `migrate up` or enable migration.runOnStart", item.Version, item.Name))
+		}
+	}
+}
+
+func migrateOnStart(db *gorm.DB) {
+	migrator, err := newMigrator(db)
+	if err != nil {
+		panic(err)
+	}
+
+	ctx, cancel := migrationContext(context.Background())
+	defer cancel()
+
+	applied, err := migrator.Up(ctx)
+	if err != nil {
+		panic(err)
+	}
+	for _, item := range applied {
+		logrus.Infof("applied migration %06d_%s", item.Version, item.Name)
+	}
+}
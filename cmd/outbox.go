@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"context"
+	"field-service/common/broker"
+	"field-service/config"
+	"field-service/repositories"
+	outboxService "field-service/services/outbox"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var outboxRelayCommand = &cobra.Command{
+	Use:   "outbox-relay",
+	Short: "Publish pending outbox events to the message broker",
+	Run: func(c *cobra.Command, args []string) {
+		_ = godotenv.Load()
+		config.Init()
+		initLogger()
+		flushTraces := initTracing()
+		defer flushTraces()
+		db, err := config.InitDatabase()
+		if err != nil {
+			panic(err)
+		}
+		defer closeDatabase(db)
+
+		publisher := initBroker()
+		defer publisher.Close()
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		logrus.Infof("outbox relay started with %s broker", config.Config.Broker.Type)
+		newOutboxRelay(repositories.NewRepositoryRegistry(db), publisher).Run(ctx)
+		logrus.Info("outbox relay stopped")
+	},
+}
+
+func newOutboxRelay(repository repositories.IRepostitoryRegistry, publisher broker.IPublisher) outboxService.IRelay {
+	return outboxService.NewRelay(repository, publisher, outboxService.RelayConfig{
+		PollInterval: time.Duration(config.Config.Outbox.PollIntervalMillisecond) * time.Millisecond,
+		BatchSize:    config.Config.Outbox.BatchSize,
+		MaxAttempts:  config.Config.Outbox.MaxAttempts,
+	})
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"context"
+	"field-service/config"
+	"field-service/repositories"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var purgeCommand = &cobra.Command{
+	Use:   "purge",
+	Short: "Permanently delete trashed fields, schedules and times past the retention period",
+	Args:  cobra.NoArgs,
+	RunE: func(c *cobra.Command, args []string) error {
+		_ = godotenv.Load()
+		config.Init()
+		initLogger()
+
+		retentionDays, err := c.Flags().GetInt("retention-days")
+		if err != nil {
+			return err
+		}
+		if !c.Flags().Changed("retention-days") {
+			retentionDays = config.Config.Trash.RetentionDay
+		}
+		if retentionDays < 1 {
+			return fmt.Errorf("retention must be at least one day")
+		}
+
+		db, err := config.InitDatabase()
+		if err != nil {
+			return err
+		}
+		defer closeDatabase(db)
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		before := time.Now().AddDate(0, 0, -retentionDays)
+		repository := repositories.NewRepositoryRegistry(db)
+
+		// Schedules go first: fields and times are only purged once nothing points to them.
+		purges := []struct {
+			name  string
+			purge func(context.Context, time.Time) (int64, error)
+		}{
+			{"field schedules", repository.GetFieldScheduleRepository().Purge},
+			{"times", repository.GetTimeRepository().Purge},
+			{"fields", repository.GetFieldRepository().Purge},
+		}
+
+		for _, item := range purges {
+			deleted, err := item.purge(ctx, before)
+			if err != nil {
+				return err
+			}
+			logrus.Infof("purged %d %s trashed before %s", deleted, item.name, before.Format(time.RFC3339))
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	purgeCommand.Flags().Int("retention-days", 0, "keep trashed records newer than this many days (defaults to trash.retentionDay)")
+}
@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"field-service/common/logger"
+	"field-service/common/tracing"
+	"field-service/config"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultReadHeaderTimeout = 10 * time.Second
+	defaultReadTimeout       = 30 * time.Second
+	defaultWriteTimeout      = 60 * time.Second
+	defaultIdleTimeout       = 120 * time.Second
+	defaultShutdownTimeout   = 30 * time.Second
+)
+
+func secondsOr(value int, fallback time.Duration) time.Duration {
+	if value <= 0 {
+		return fallback
+	}
+
+	return time.Duration(value) * time.Second
+}
+
+func newHTTPServer(handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              fmt.Sprintf(":%d", config.Config.Port),
+		Handler:           handler,
+		ReadHeaderTimeout: secondsOr(config.Config.Server.ReadHeaderTimeoutSecond, defaultReadHeaderTimeout),
+		ReadTimeout:       secondsOr(config.Config.Server.ReadTimeoutSecond, defaultReadTimeout),
+		WriteTimeout:      secondsOr(config.Config.Server.WriteTimeoutSecond, defaultWriteTimeout),
+		IdleTimeout:       secondsOr(config.Config.Server.IdleTimeoutSecond, defaultIdleTimeout),
+		MaxHeaderBytes:    config.Config.Server.MaxHeaderBytes,
+	}
+}
+
+func shutdownTimeout() time.Duration {
+	return secondsOr(config.Config.Server.ShutdownTimeoutSecond, defaultShutdownTimeout)
+}
+
+// workers tracks the background goroutines started by serve so shutdown can
+// wait for them after their context is cancelled.
+type workers struct {
+	wg sync.WaitGroup
+}
+
+func (w *workers) Go(name string, run func()) {
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		run()
+		logrus.Infof("%s stopped", name)
+	}()
+}
+
+// Wait blocks until every worker returned or ctx is done.
+func (w *workers) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		w.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func stopGRPC(ctx context.Context, server *grpc.Server) {
+	done := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		logrus.Warn("gRPC server did not stop in time, closing open connections")
+		server.Stop()
+	}
+}
+
+func closeDatabase(db *gorm.DB) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		logrus.Errorf("failed to get database instance: %v", err)
+		return
+	}
+
+	err = sqlDB.Close()
+	if err != nil {
+		logrus.Errorf("failed to close database: %v", err)
+	}
+}
+
+// shutdown stops accepting requests, lets in-flight requests and workers
+// finish within the configured timeout, and only then closes the pool they use.
+func shutdown(httpServer *http.Server, grpcServer *grpc.Server, background *workers, closers []func() error, db *gorm.DB) {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout())
+	defer cancel()
+
+	logrus.Info("shutting down server")
+	err := httpServer.Shutdown(ctx)
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		logrus.Errorf("http server did not shut down cleanly: %v", err)
+	}
+
+	if grpcServer != nil {
+		stopGRPC(ctx, grpcServer)
+	}
+
+	err = background.Wait(ctx)
+	if err != nil {
+		logrus.Warnf("background workers did not stop in time: %v", err)
+	}
+
+	for _, closer := range closers {
+		err = closer()
+		if err != nil {
+			logrus.Errorf("failed to close resource: %v", err)
+		}
+	}
+
+	closeDatabase(db)
+	logrus.Info("server stopped")
+}
+
+// initLogger must run right after config.Init so that nothing logged afterwards
+// can leak a secret from the loaded configuration.
+func initLogger() {
+	logger.RegisterSecrets(&config.Config)
+	logger.Init(logger.Config{
+		Level:  config.Config.Log.Level,
+		Format: config.Config.Log.Format,
+	})
+}
+
+// initTracing sets up tracing for a command and returns the flush function to run on exit.
+func initTracing() func() error {
+	shutdownTracing, err := tracing.Init(context.Background(), tracing.Config{
+		ServiceName: config.Config.AppName,
+		Environment: config.Config.AppEnv,
+		Exporter:    config.Config.Tracing.Exporter,
+		Endpoint:    config.Config.Tracing.Endpoint,
+		Insecure:    config.Config.Tracing.Insecure,
+		Headers:     config.Config.Tracing.Headers,
+		FilePath:    config.Config.Tracing.FilePath,
+		SampleRatio: config.Config.Tracing.SampleRatio,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return shutdownTracing(ctx)
+	}
+}
@@ -0,0 +1,28 @@
+package broker
+
+import "context"
+
+type Message struct {
+	ID      string
+	Topic   string
+	Key     string
+	Payload []byte
+	Headers map[string]string
+}
+
+type Handler func(context.Context, Message) error
+
+type IPublisher interface {
+	Publish(context.Context, Message) error
+	Close() error
+}
+
+type ISubscriber interface {
+	Subscribe(context.Context, string, Handler) error
+	Close() error
+}
+
+type IBroker interface {
+	IPublisher
+	ISubscriber
+}
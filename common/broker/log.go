@@ -0,0 +1,32 @@
+package broker
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+// LogBroker writes published messages to the log. It is meant for local runs
+// where no broker is available; subscriptions never receive anything.
+type LogBroker struct{}
+
+func NewLogBroker() IBroker {
+	return &LogBroker{}
+}
+
+func (l *LogBroker) Publish(_ context.Context, message Message) error {
+	logrus.WithFields(logrus.Fields{
+		"id":    message.ID,
+		"topic": message.Topic,
+		"key":   message.Key,
+	}).Infof("publish message: %s", message.Payload)
+	return nil
+}
+
+func (l *LogBroker) Subscribe(context.Context, string, Handler) error {
+	return nil
+}
+
+func (l *LogBroker) Close() error {
+	return nil
+}
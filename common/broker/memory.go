@@ -0,0 +1,47 @@
+package broker
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// MemoryBroker delivers messages synchronously to in-process subscribers.
+type MemoryBroker struct {
+	mutex    sync.RWMutex
+	handlers map[string][]Handler
+}
+
+func NewMemoryBroker() IBroker {
+	return &MemoryBroker{
+		handlers: make(map[string][]Handler),
+	}
+}
+
+func (m *MemoryBroker) Publish(ctx context.Context, message Message) error {
+	m.mutex.RLock()
+	handlers := m.handlers[message.Topic]
+	m.mutex.RUnlock()
+
+	for _, handler := range handlers {
+		err := handler(ctx, message)
+		if err != nil {
+			logrus.Errorf("memory broker handler for %s failed: %v", message.Topic, err)
+		}
+	}
+
+	return nil
+}
+
+func (m *MemoryBroker) Subscribe(_ context.Context, topic string, handler Handler) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.handlers[topic] = append(m.handlers[topic], handler)
+	return nil
+}
+
+func (m *MemoryBroker) Close() error {
+	return nil
+}
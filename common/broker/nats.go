@@ -0,0 +1,191 @@
+package broker
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	natsMessageIDHeader = "Nats-Msg-Id"
+	natsPublishTimeout  = 5 * time.Second
+	natsAckWait         = 30 * time.Second
+	natsNakDelay        = 5 * time.Second
+)
+
+type NATSBroker struct {
+	conn          *nats.Conn
+	js            nats.JetStreamContext
+	subjectPrefix string
+	queueGroup    string
+	mutex         sync.Mutex
+	subscriptions []*nats.Subscription
+}
+
+func NewNATSBroker(url, subjectPrefix, queueGroup string) (IBroker, error) {
+	conn, err := nats.Connect(url)
+	if err != nil {
+		logrus.Errorf("failed to connect to nats: %v", err)
+		return nil, err
+	}
+
+	js, err := conn.JetStream()
+	if err != nil {
+		logrus.Errorf("failed to open nats jetstream: %v", err)
+		conn.Close()
+		return nil, err
+	}
+
+	return &NATSBroker{
+		conn:          conn,
+		js:            js,
+		subjectPrefix: subjectPrefix,
+		queueGroup:    queueGroup,
+	}, nil
+}
+
+func (n *NATSBroker) subject(topic string) string {
+	return n.subjectPrefix + topic
+}
+
+// Publish returns once a JetStream stream has stored the message, so the caller
+// may treat it as sent. A stream must cover the subject; the message ID header
+// lets the stream drop a duplicate when a publish is retried after a lost ack.
+func (n *NATSBroker) Publish(ctx context.Context, message Message) error {
+	ctx, cancel := context.WithTimeout(ctx, natsPublishTimeout)
+	defer cancel()
+
+	msg := nats.NewMsg(n.subject(message.Topic))
+	msg.Data = message.Payload
+	msg.Header.Set(natsMessageIDHeader, message.ID)
+	for key, value := range message.Headers {
+		msg.Header.Set(key, value)
+	}
+
+	_, err := n.js.PublishMsg(msg, nats.Context(ctx))
+	return err
+}
+
+// durableName names the JetStream consumer for a topic. Every instance in the
+// queue group binds to the same durable, so messages stored while all of them
+// were down are delivered once one comes back.
+func (n *NATSBroker) durableName(topic string) string {
+	return strings.NewReplacer(".", "_", "*", "_", ">", "_").Replace(n.queueGroup + "_" + topic)
+}
+
+// ensureConsumer creates the durable consumer for a topic unless it exists.
+// Creating it here rather than through the subscription keeps the library from
+// deleting it when this instance unsubscribes on shutdown.
+func (n *NATSBroker) ensureConsumer(topic string) (string, string, error) {
+	subject := n.subject(topic)
+	stream, err := n.js.StreamNameBySubject(subject)
+	if err != nil {
+		return "", "", err
+	}
+
+	durable := n.durableName(topic)
+	_, err = n.js.ConsumerInfo(stream, durable)
+	if err == nil {
+		return stream, durable, nil
+	}
+	if !errors.Is(err, nats.ErrConsumerNotFound) {
+		return "", "", err
+	}
+
+	_, err = n.js.AddConsumer(stream, &nats.ConsumerConfig{
+		Durable:        durable,
+		DeliverSubject: nats.NewInbox(),
+		DeliverGroup:   n.queueGroup,
+		DeliverPolicy:  nats.DeliverAllPolicy,
+		AckPolicy:      nats.AckExplicitPolicy,
+		AckWait:        natsAckWait,
+		FilterSubject:  subject,
+	})
+	if err != nil {
+		// Another instance may have created it first.
+		if _, infoErr := n.js.ConsumerInfo(stream, durable); infoErr != nil {
+			return "", "", err
+		}
+	}
+
+	return stream, durable, nil
+}
+
+// Subscribe binds to a durable JetStream consumer and acks a message only after
+// the handler returns without error; otherwise it is redelivered after a delay.
+func (n *NATSBroker) Subscribe(ctx context.Context, topic string, handler Handler) error {
+	stream, durable, err := n.ensureConsumer(topic)
+	if err != nil {
+		return err
+	}
+
+	subscription, err := n.js.QueueSubscribe(n.subject(topic), n.queueGroup, func(msg *nats.Msg) {
+		headers := make(map[string]string, len(msg.Header))
+		for key := range msg.Header {
+			headers[key] = msg.Header.Get(key)
+		}
+
+		// The handler retries with backoff, which may outlast the ack wait;
+		// keep the message claimed until it returns.
+		done := make(chan struct{})
+		go func() {
+			ticker := time.NewTicker(natsAckWait / 2)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-done:
+					return
+				case <-ticker.C:
+					_ = msg.InProgress()
+				}
+			}
+		}()
+
+		err := handler(ctx, Message{
+			ID:      msg.Header.Get(natsMessageIDHeader),
+			Topic:   topic,
+			Payload: msg.Data,
+			Headers: headers,
+		})
+		close(done)
+
+		if err != nil {
+			logrus.Errorf("nats handler for %s failed: %v", topic, err)
+			if nakErr := msg.NakWithDelay(natsNakDelay); nakErr != nil {
+				logrus.Errorf("failed to nak nats message on %s: %v", topic, nakErr)
+			}
+			return
+		}
+
+		if ackErr := msg.Ack(); ackErr != nil {
+			logrus.Errorf("failed to ack nats message on %s: %v", topic, ackErr)
+		}
+	},
+		nats.Bind(stream, durable),
+		nats.ManualAck(),
+	)
+	if err != nil {
+		return err
+	}
+
+	n.mutex.Lock()
+	n.subscriptions = append(n.subscriptions, subscription)
+	n.mutex.Unlock()
+	return nil
+}
+
+func (n *NATSBroker) Close() error {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	for _, subscription := range n.subscriptions {
+		_ = subscription.Unsubscribe()
+	}
+
+	return n.conn.Drain()
+}
@@ -0,0 +1,149 @@
+package cursor
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	errConstants "field-service/constants/error"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Key is one column of a keyset ordering. Column is written into the SQL as is,
+// so it must come from code and never from the request.
+type Key struct {
+	Column string
+	Desc   bool
+}
+
+type payload struct {
+	Scope  string   `json:"s"`
+	Order  string   `json:"o"`
+	Values []string `json:"v"`
+}
+
+var secret []byte
+
+// Init sets the key cursors are signed with. Without one a random key is used,
+// so cursors stop working after a restart and are not shared between instances.
+func Init(key string) {
+	if key != "" {
+		secret = []byte(key)
+		return
+	}
+
+	secret = make([]byte, sha256.Size)
+	_, err := rand.Read(secret)
+	if err != nil {
+		panic(err)
+	}
+
+	logrus.Warn("pagination cursor secret is not set, using a random key")
+}
+
+// Order renders keys as an ORDER BY list.
+func Order(keys []Key) string {
+	columns := make([]string, 0, len(keys))
+	for _, key := range keys {
+		direction := "asc"
+		if key.Desc {
+			direction = "desc"
+		}
+		columns = append(columns, fmt.Sprintf("%s %s", key.Column, direction))
+	}
+
+	return strings.Join(columns, ", ")
+}
+
+// After returns the condition matching rows that sort after values. Values are
+// passed as text and converted by postgres to each column's type.
+func After(keys []Key, values []string) (string, []any) {
+	var (
+		conditions = make([]string, 0, len(keys))
+		args       = make([]any, 0, len(keys)*(len(keys)+1)/2)
+	)
+
+	for i, key := range keys {
+		parts := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			parts = append(parts, fmt.Sprintf("%s = ?", keys[j].Column))
+			args = append(args, values[j])
+		}
+
+		operator := ">"
+		if key.Desc {
+			operator = "<"
+		}
+		parts = append(parts, fmt.Sprintf("%s %s ?", key.Column, operator))
+		args = append(args, values[i])
+
+		conditions = append(conditions, fmt.Sprintf("(%s)", strings.Join(parts, " AND ")))
+	}
+
+	return strings.Join(conditions, " OR "), args
+}
+
+func sign(data []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// Encode signs the sort values of the last row of a page. Scope names the
+// listing, so a cursor cannot be replayed against another one.
+func Encode(scope string, keys []Key, values []string) string {
+	data, _ := json.Marshal(payload{
+		Scope:  scope,
+		Order:  Order(keys),
+		Values: values,
+	})
+
+	return fmt.Sprintf("%s.%s",
+		base64.RawURLEncoding.EncodeToString(data),
+		base64.RawURLEncoding.EncodeToString(sign(data)))
+}
+
+// Decode verifies a cursor and returns its sort values. A cursor that was
+// altered, or issued for another listing or ordering, is rejected.
+func Decode(scope string, keys []Key, token string) ([]string, error) {
+	encodedData, encodedSignature, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, errConstants.ErrInvalidCursor
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(encodedData)
+	if err != nil {
+		return nil, errConstants.ErrInvalidCursor
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(signature, sign(data)) {
+		return nil, errConstants.ErrInvalidCursor
+	}
+
+	var decoded payload
+	err = json.Unmarshal(data, &decoded)
+	if err != nil {
+		return nil, errConstants.ErrInvalidCursor
+	}
+
+	if decoded.Scope != scope || decoded.Order != Order(keys) || len(decoded.Values) != len(keys) {
+		return nil, errConstants.ErrInvalidCursor
+	}
+
+	return decoded.Values, nil
+}
+
+// FormatTime renders a timestamp sort value without losing precision.
+func FormatTime(value *time.Time) string {
+	if value == nil {
+		return time.Time{}.Format(time.RFC3339Nano)
+	}
+
+	return value.Format(time.RFC3339Nano)
+}
@@ -0,0 +1,166 @@
+package cursor
+
+import (
+	"encoding/base64"
+	"errors"
+	errConstants "field-service/constants/error"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecode(t *testing.T) {
+	Init("test-secret")
+
+	keys := []Key{{Column: "fields.name"}, {Column: "fields.id"}}
+	values := []string{"Futsal Arena", "42"}
+	token := Encode("fields", keys, values)
+	data, signature, _ := strings.Cut(token, ".")
+
+	forged := base64.RawURLEncoding.EncodeToString(
+		[]byte(`{"s":"fields","o":"fields.name asc, fields.id asc","v":["Zzz","1"]}`))
+
+	tests := []struct {
+		name    string
+		scope   string
+		keys    []Key
+		token   string
+		want    []string
+		wantErr error
+	}{
+		{
+			name:  "round trip",
+			scope: "fields",
+			keys:  keys,
+			token: token,
+			want:  values,
+		},
+		{
+			name:    "payload replaced",
+			scope:   "fields",
+			keys:    keys,
+			token:   forged + "." + signature,
+			wantErr: errConstants.ErrInvalidCursor,
+		},
+		{
+			name:    "signature replaced",
+			scope:   "fields",
+			keys:    keys,
+			token:   data + "." + base64.RawURLEncoding.EncodeToString([]byte("not a signature")),
+			wantErr: errConstants.ErrInvalidCursor,
+		},
+		{
+			name:    "signature dropped",
+			scope:   "fields",
+			keys:    keys,
+			token:   data,
+			wantErr: errConstants.ErrInvalidCursor,
+		},
+		{
+			name:    "not base64",
+			scope:   "fields",
+			keys:    keys,
+			token:   "%%%." + signature,
+			wantErr: errConstants.ErrInvalidCursor,
+		},
+		{
+			name:    "other listing",
+			scope:   "field-schedules",
+			keys:    keys,
+			token:   token,
+			wantErr: errConstants.ErrInvalidCursor,
+		},
+		{
+			name:    "other ordering",
+			scope:   "fields",
+			keys:    []Key{{Column: "fields.name", Desc: true}, {Column: "fields.id", Desc: true}},
+			token:   token,
+			wantErr: errConstants.ErrInvalidCursor,
+		},
+		{
+			name:    "other columns",
+			scope:   "fields",
+			keys:    []Key{{Column: "fields.code"}, {Column: "fields.id"}},
+			token:   token,
+			wantErr: errConstants.ErrInvalidCursor,
+		},
+		{
+			name:    "empty",
+			scope:   "fields",
+			keys:    keys,
+			token:   "",
+			wantErr: errConstants.ErrInvalidCursor,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Decode(tt.scope, tt.keys, tt.token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Decode() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Decode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeAfterSecretChange(t *testing.T) {
+	keys := []Key{{Column: "fields.id"}}
+
+	Init("old-secret")
+	token := Encode("fields", keys, []string{"1"})
+
+	Init("new-secret")
+	_, err := Decode("fields", keys, token)
+	if !errors.Is(err, errConstants.ErrInvalidCursor) {
+		t.Errorf("Decode() error = %v, want %v", err, errConstants.ErrInvalidCursor)
+	}
+}
+
+func TestAfter(t *testing.T) {
+	tests := []struct {
+		name     string
+		keys     []Key
+		values   []string
+		wantSQL  string
+		wantArgs []any
+	}{
+		{
+			name:     "single key",
+			keys:     []Key{{Column: "id"}},
+			values:   []string{"7"},
+			wantSQL:  "(id > ?)",
+			wantArgs: []any{"7"},
+		},
+		{
+			name:     "descending with tie breaker",
+			keys:     []Key{{Column: "name", Desc: true}, {Column: "id", Desc: true}},
+			values:   []string{"b", "7"},
+			wantSQL:  "(name < ?) OR (name = ? AND id < ?)",
+			wantArgs: []any{"b", "b", "7"},
+		},
+		{
+			name:     "mixed directions",
+			keys:     []Key{{Column: "price"}, {Column: "name", Desc: true}, {Column: "id", Desc: true}},
+			values:   []string{"100", "b", "7"},
+			wantSQL:  "(price > ?) OR (price = ? AND name < ?) OR (price = ? AND name = ? AND id < ?)",
+			wantArgs: []any{"100", "100", "b", "100", "b", "7"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSQL, gotArgs := After(tt.keys, tt.values)
+			if gotSQL != tt.wantSQL {
+				t.Errorf("After() sql = %q, want %q", gotSQL, tt.wantSQL)
+			}
+
+			if !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+				t.Errorf("After() args = %v, want %v", gotArgs, tt.wantArgs)
+			}
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package error
 
 import (
+	"context"
 	"errors"
+	"field-service/common/logger"
 	"fmt"
 	"strings"
 
@@ -66,3 +68,10 @@ func WrapError(err error) error {
 	logrus.Errorf("error: %v", err)
 	return err
 }
+
+// WrapErrorContext is WrapError for code that has the request context, so the
+// log line can be tied back to the request that caused it.
+func WrapErrorContext(ctx context.Context, err error) error {
+	logger.FromContext(ctx).Errorf("error: %v", err)
+	return err
+}
@@ -4,12 +4,17 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"field-service/common/logger"
+	"field-service/common/metrics"
+	"field-service/common/tracing"
 	"fmt"
 	"io"
 	"time"
 
 	"cloud.google.com/go/storage"
-	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/api/option"
 )
 
@@ -34,6 +39,7 @@ type GCSClient struct {
 
 type IGCSClient interface {
 	UploadFile(context.Context, string, []byte) (string, error)
+	Ping(context.Context) error
 }
 
 func NewGCSClient(ServiceAccountKeyJSON ServiceAccountKeyJSON, BucketName string) IGCSClient {
@@ -47,14 +53,14 @@ func (g *GCSClient) createClient(ctx context.Context) (*storage.Client, error) {
 	reqBodyBytes := new(bytes.Buffer)
 	err := json.NewEncoder(reqBodyBytes).Encode(g.ServiceAccountKeyJSON)
 	if err != nil {
-		logrus.Errorf("Error encoding service account key: %v", err)
+		logger.FromContext(ctx).Errorf("Error encoding service account key: %v", err)
 		return nil, err
 	}
 
 	jsonBytes := reqBodyBytes.Bytes()
 	client, err := storage.NewClient(ctx, option.WithCredentialsJSON(jsonBytes))
 	if err != nil {
-		logrus.Errorf("Error creating storage client: %v", err)
+		logger.FromContext(ctx).Errorf("Error creating storage client: %v", err)
 		return nil, err
 	}
 
@@ -62,6 +68,30 @@ func (g *GCSClient) createClient(ctx context.Context) (*storage.Client, error) {
 }
 
 func (g *GCSClient) UploadFile(ctx context.Context, filename string, data []byte) (string, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "GCSClient.UploadFile",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("storage.bucket", g.BucketName),
+			attribute.String("storage.object", filename),
+			attribute.Int("storage.size", len(data)),
+		),
+	)
+	defer span.End()
+
+	start := time.Now()
+	fileURL, err := g.uploadFile(ctx, filename, data)
+	outcome := metrics.OutcomeSuccess
+	if err != nil {
+		outcome = metrics.OutcomeError
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	metrics.StorageUploadDuration.WithLabelValues(outcome).Observe(time.Since(start).Seconds())
+
+	return fileURL, err
+}
+
+func (g *GCSClient) uploadFile(ctx context.Context, filename string, data []byte) (string, error) {
 	var (
 		contentType      = "application/octet-stream"
 		timeoutInSeconds = 60
@@ -69,14 +99,14 @@ func (g *GCSClient) UploadFile(ctx context.Context, filename string, data []byte
 
 	client, err := g.createClient(ctx)
 	if err != nil {
-		logrus.Errorf("Error creating storage client: %v", err)
+		logger.FromContext(ctx).Errorf("Error creating storage client: %v", err)
 		return "", err
 	}
 
 	defer func(client *storage.Client) {
 		err := client.Close()
 		if err != nil {
-			logrus.Errorf("Error closing storage client: %v", err)
+			logger.FromContext(ctx).Errorf("Error closing storage client: %v", err)
 			return
 		}
 	}(client)
@@ -94,19 +124,19 @@ func (g *GCSClient) UploadFile(ctx context.Context, filename string, data []byte
 
 	_, err = io.Copy(writer, buffer)
 	if err != nil {
-		logrus.Errorf("Error copying data to writer: %v", err)
+		logger.FromContext(ctx).Errorf("Error copying data to writer: %v", err)
 		return "", err
 	}
 
 	err = writer.Close()
 	if err != nil {
-		logrus.Errorf("failed to close: %v", err)
+		logger.FromContext(ctx).Errorf("failed to close: %v", err)
 		return "", err
 	}
 
 	_, err = object.Update(ctx, storage.ObjectAttrsToUpdate{ContentType: contentType})
 	if err != nil {
-		logrus.Errorf("Error updating object: %v", err)
+		logger.FromContext(ctx).Errorf("Error updating object: %v", err)
 		return "", err
 	}
 
@@ -114,3 +144,23 @@ func (g *GCSClient) UploadFile(ctx context.Context, filename string, data []byte
 
 	return fileURL, nil
 }
+
+// Ping checks that the credentials work and the bucket is reachable.
+func (g *GCSClient) Ping(ctx context.Context) error {
+	ctx, span := tracing.Tracer().Start(ctx, "GCSClient.Ping", trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+
+	client, err := g.createClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	_, err = client.Bucket(g.BucketName).Attrs(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Errorf("Error reading bucket attributes: %v", err)
+		return err
+	}
+
+	return nil
+}
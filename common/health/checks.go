@@ -0,0 +1,56 @@
+package health
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PoolStats struct {
+	MaxOpenConnections int   `json:"maxOpenConnections"`
+	OpenConnections    int   `json:"openConnections"`
+	InUse              int   `json:"inUse"`
+	Idle               int   `json:"idle"`
+	WaitCount          int64 `json:"waitCount"`
+	WaitDurationMs     int64 `json:"waitDurationMs"`
+}
+
+// DatabaseCheck pings the pool behind gorm and reports its statistics.
+func DatabaseCheck(db *gorm.DB, timeout time.Duration) Check {
+	return Check{
+		Name:     "database",
+		Critical: true,
+		Timeout:  timeout,
+		Run: func(ctx context.Context) (any, error) {
+			sqlDB, err := db.DB()
+			if err != nil {
+				return nil, err
+			}
+
+			stats := sqlDB.Stats()
+			details := PoolStats{
+				MaxOpenConnections: stats.MaxOpenConnections,
+				OpenConnections:    stats.OpenConnections,
+				InUse:              stats.InUse,
+				Idle:               stats.Idle,
+				WaitCount:          stats.WaitCount,
+				WaitDurationMs:     stats.WaitDuration.Milliseconds(),
+			}
+
+			return details, sqlDB.PingContext(ctx)
+		},
+	}
+}
+
+// PingCheck wraps a dependency that exposes a Ping method.
+func PingCheck(name string, critical bool, timeout time.Duration, ping func(context.Context) error) Check {
+	return Check{
+		Name:     name,
+		Critical: critical,
+		Timeout:  timeout,
+		Run: func(ctx context.Context) (any, error) {
+			return nil, ping(ctx)
+		},
+	}
+}
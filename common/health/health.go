@@ -0,0 +1,138 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+
+	defaultTimeout = 2 * time.Second
+)
+
+// Check is one dependency probe. A failing critical check makes the service
+// not ready; a failing non-critical check is only reported.
+type Check struct {
+	Name     string
+	Critical bool
+	Timeout  time.Duration
+	Run      func(context.Context) (any, error)
+}
+
+type CheckResult struct {
+	Status     string `json:"status"`
+	Critical   bool   `json:"critical"`
+	DurationMs int64  `json:"durationMs"`
+	Error      string `json:"error,omitempty"`
+	Details    any    `json:"details,omitempty"`
+}
+
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+type Checker struct {
+	checks []Check
+}
+
+type IChecker interface {
+	Check(context.Context) Report
+	Liveness(*gin.Context)
+	Readiness(*gin.Context)
+}
+
+func NewChecker(checks ...Check) IChecker {
+	return &Checker{checks: checks}
+}
+
+func (h *Checker) run(ctx context.Context, check Check) CheckResult {
+	timeout := check.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type outcome struct {
+		details any
+		err     error
+	}
+
+	// The probe runs in its own goroutine so a dependency that ignores the
+	// context still cannot hold the response past the timeout.
+	start := time.Now()
+	done := make(chan outcome, 1)
+	go func() {
+		details, err := check.Run(ctx)
+		done <- outcome{details: details, err: err}
+	}()
+
+	result := CheckResult{Status: StatusUp, Critical: check.Critical}
+	select {
+	case o := <-done:
+		result.Details = o.details
+		if o.err != nil {
+			result.Status = StatusDown
+			result.Error = o.err.Error()
+		}
+	case <-ctx.Done():
+		result.Status = StatusDown
+		result.Error = ctx.Err().Error()
+	}
+
+	result.DurationMs = time.Since(start).Milliseconds()
+	return result
+}
+
+// Check runs every probe concurrently and aggregates the results.
+func (h *Checker) Check(ctx context.Context) Report {
+	var (
+		mutex sync.Mutex
+		wg    sync.WaitGroup
+	)
+
+	report := Report{Status: StatusUp, Checks: make(map[string]CheckResult, len(h.checks))}
+	for _, check := range h.checks {
+		wg.Add(1)
+		go func(check Check) {
+			defer wg.Done()
+			result := h.run(ctx, check)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			report.Checks[check.Name] = result
+			if result.Status == StatusDown && check.Critical {
+				report.Status = StatusDown
+			}
+		}(check)
+	}
+
+	wg.Wait()
+	return report
+}
+
+// Liveness only tells that the process is serving requests.
+func (h *Checker) Liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status": StatusUp,
+	})
+}
+
+func (h *Checker) Readiness(c *gin.Context) {
+	report := h.Check(c.Request.Context())
+	code := http.StatusOK
+	if report.Status != StatusUp {
+		code = http.StatusServiceUnavailable
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(code, report)
+}
@@ -0,0 +1,64 @@
+package logger
+
+import (
+	"context"
+	"field-service/constants"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+type Config struct {
+	Level  string
+	Format string
+}
+
+// Init switches the standard logrus logger to the configured format and level and
+// installs the redaction hook, so every package that logs through logrus is covered.
+func Init(config Config) {
+	logrus.SetOutput(os.Stdout)
+	if config.Format == FormatText {
+		logrus.SetFormatter(&logrus.TextFormatter{FullTimestamp: true})
+	} else {
+		logrus.SetFormatter(&logrus.JSONFormatter{})
+	}
+
+	level, err := logrus.ParseLevel(config.Level)
+	if err != nil {
+		level = logrus.InfoLevel
+	}
+	logrus.SetLevel(level)
+	logrus.AddHook(redactor)
+}
+
+// FromContext returns an entry carrying the request ID, calling service, user and
+// trace of the request the context belongs to. Missing values are left out.
+func FromContext(ctx context.Context) *logrus.Entry {
+	entry := logrus.NewEntry(logrus.StandardLogger())
+	if ctx == nil {
+		return entry
+	}
+
+	fields := logrus.Fields{}
+	if requestID, ok := ctx.Value(constants.RequestID).(string); ok && requestID != "" {
+		fields["request_id"] = requestID
+	}
+	if serviceName, ok := ctx.Value(constants.ServiceName).(string); ok && serviceName != "" {
+		fields["service"] = serviceName
+	}
+	if userUUID, ok := ctx.Value(constants.UserUUID).(string); ok && userUUID != "" {
+		fields["user"] = userUUID
+	}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		fields["trace_id"] = spanContext.TraceID().String()
+		fields["span_id"] = spanContext.SpanID().String()
+	}
+
+	return entry.WithContext(ctx).WithFields(fields)
+}
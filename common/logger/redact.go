@@ -0,0 +1,138 @@
+package logger
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	secretTag = "secret"
+	redacted  = "[REDACTED]"
+
+	// Very short values would blank out unrelated words in every log line.
+	minSecretLength = 4
+)
+
+var redactor = &redactHook{}
+
+type redactHook struct {
+	mu       sync.RWMutex
+	replacer *strings.Replacer
+}
+
+// RegisterSecrets walks value and remembers every string field tagged `secret:"true"`,
+// including strings inside slices and maps, so they never reach the log output.
+func RegisterSecrets(value any) {
+	secrets := map[string]struct{}{}
+	collectSecrets(reflect.ValueOf(value), false, secrets)
+
+	values := make([]string, 0, len(secrets))
+	for secret := range secrets {
+		values = append(values, secret)
+	}
+	// Longer secrets first, so a secret containing another is replaced as a whole.
+	sort.Slice(values, func(i, j int) bool {
+		return len(values[i]) > len(values[j])
+	})
+
+	pairs := make([]string, 0, len(values)*2)
+	for _, secret := range values {
+		pairs = append(pairs, secret, redacted)
+	}
+
+	redactor.mu.Lock()
+	defer redactor.mu.Unlock()
+	if len(pairs) == 0 {
+		redactor.replacer = nil
+		return
+	}
+	redactor.replacer = strings.NewReplacer(pairs...)
+}
+
+func collectSecrets(value reflect.Value, secret bool, secrets map[string]struct{}) {
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !value.IsNil() {
+			collectSecrets(value.Elem(), secret, secrets)
+		}
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			collectSecrets(value.Field(i), secret || field.Tag.Get(secretTag) == "true", secrets)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			collectSecrets(value.Index(i), secret, secrets)
+		}
+	case reflect.Map:
+		iterator := value.MapRange()
+		for iterator.Next() {
+			collectSecrets(iterator.Value(), secret, secrets)
+		}
+	case reflect.String:
+		if secret {
+			addSecret(value.String(), secrets)
+		}
+	}
+}
+
+func addSecret(secret string, secrets map[string]struct{}) {
+	secret = strings.TrimSpace(secret)
+	if len(secret) < minSecretLength {
+		return
+	}
+
+	secrets[secret] = struct{}{}
+	// Keys such as the GCS private key are stored with escaped newlines and
+	// unescaped before use, so both spellings must be caught.
+	if unescaped := strings.ReplaceAll(secret, `\n`, "\n"); unescaped != secret {
+		secrets[unescaped] = struct{}{}
+		for _, line := range strings.Split(unescaped, "\n") {
+			if line = strings.TrimSpace(line); len(line) >= minSecretLength && !strings.HasPrefix(line, "-----") {
+				secrets[line] = struct{}{}
+			}
+		}
+	}
+}
+
+// Redact replaces every registered secret in text.
+func Redact(text string) string {
+	redactor.mu.RLock()
+	replacer := redactor.replacer
+	redactor.mu.RUnlock()
+
+	if replacer == nil {
+		return text
+	}
+	return replacer.Replace(text)
+}
+
+func (h *redactHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *redactHook) Fire(entry *logrus.Entry) error {
+	entry.Message = Redact(entry.Message)
+
+	// Data is shared with the entry the caller built, so it is copied before rewriting.
+	data := make(logrus.Fields, len(entry.Data))
+	for key, value := range entry.Data {
+		switch typed := value.(type) {
+		case string:
+			data[key] = Redact(typed)
+		case error:
+			data[key] = Redact(typed.Error())
+		default:
+			data[key] = value
+		}
+	}
+	entry.Data = data
+	return nil
+}
@@ -0,0 +1,88 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"field-service/domain/dto"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/sirupsen/logrus"
+)
+
+const availableSlotsTimeout = 5 * time.Second
+
+// RegisterDatabase exports the pool statistics of db.
+func RegisterDatabase(db *sql.DB) {
+	prometheus.MustRegister(collectors.NewDBStatsCollector(db, "field_service"))
+}
+
+// RegisterUserCache exports the user client cache counters read through stats.
+func RegisterUserCache(stats func() (hits uint64, misses uint64, size int)) {
+	prometheus.MustRegister(
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "user_cache_hits_total",
+			Help:      "User lookups answered from the cache.",
+		}, func() float64 {
+			hits, _, _ := stats()
+			return float64(hits)
+		}),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "user_cache_misses_total",
+			Help:      "User lookups that went to the user service.",
+		}, func() float64 {
+			_, misses, _ := stats()
+			return float64(misses)
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "user_cache_entries",
+			Help:      "Users currently held in the cache.",
+		}, func() float64 {
+			_, _, size := stats()
+			return float64(size)
+		}),
+	)
+}
+
+type availableSlotsCollector struct {
+	desc  *prometheus.Desc
+	count func(context.Context) ([]dto.AvailableSlotCount, error)
+}
+
+// RegisterAvailableSlots exports the number of available upcoming slots per
+// field. count is queried on every scrape.
+func RegisterAvailableSlots(count func(context.Context) ([]dto.AvailableSlotCount, error)) {
+	prometheus.MustRegister(&availableSlotsCollector{
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "field_available_slots"),
+			"Available field schedule slots from today onwards, by field.",
+			[]string{"field_uuid", "field_name"},
+			nil,
+		),
+		count: count,
+	})
+}
+
+func (a *availableSlotsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- a.desc
+}
+
+func (a *availableSlotsCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), availableSlotsTimeout)
+	defer cancel()
+
+	counts, err := a.count(ctx)
+	if err != nil {
+		logrus.Errorf("failed to count available slots: %v", err)
+		ch <- prometheus.NewInvalidMetric(a.desc, err)
+		return
+	}
+
+	for _, item := range counts {
+		ch <- prometheus.MustNewConstMetric(a.desc, prometheus.GaugeValue, float64(item.Total), item.FieldUUID.String(), item.FieldName)
+	}
+}
@@ -0,0 +1,88 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const namespace = "field_service"
+
+var (
+	HTTPRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests handled, by method, route and status code.",
+	}, []string{"method", "route", "status"})
+
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency, by method, route and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	UserClientRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "user_client_request_duration_seconds",
+		Help:      "Latency of calls to the user service, by outcome.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"outcome"})
+
+	UserClientErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "user_client_errors_total",
+		Help:      "Failed calls to the user service, by reason.",
+	}, []string{"reason"})
+
+	StorageUploadDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "storage_upload_duration_seconds",
+		Help:      "Duration of file uploads to object storage, by outcome.",
+		Buckets:   []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"outcome"})
+
+	RateLimiterRejectionsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rate_limiter_rejections_total",
+		Help:      "Requests rejected by the rate limiter.",
+	})
+
+	FieldSchedulesGeneratedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "field_schedules_generated_total",
+		Help:      "Field schedule slots created, by source.",
+	}, []string{"source"})
+
+	FieldSchedulesBookedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "field_schedules_booked_total",
+		Help:      "Field schedule slots moved to booked.",
+	})
+
+	FieldSchedulesReleasedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "field_schedules_released_total",
+		Help:      "Field schedule slots moved back to available.",
+	})
+)
+
+const (
+	OutcomeSuccess = "success"
+	OutcomeError   = "error"
+
+	SourceManual   = "manual"
+	SourceGenerate = "generate"
+)
+
+func init() {
+	prometheus.MustRegister(
+		HTTPRequestsTotal,
+		HTTPRequestDuration,
+		UserClientRequestDuration,
+		UserClientErrorsTotal,
+		StorageUploadDuration,
+		RateLimiterRejectionsTotal,
+		FieldSchedulesGeneratedTotal,
+		FieldSchedulesBookedTotal,
+		FieldSchedulesReleasedTotal,
+	)
+}
@@ -0,0 +1,119 @@
+package migration
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name         string
+		files        fstest.MapFS
+		wantVersions []uint64
+		wantNames    []string
+		wantErr      bool
+	}{
+		{
+			name: "ordered by version, not by file name",
+			files: fstest.MapFS{
+				"10_add_index.up.sql":     {Data: []byte("CREATE INDEX a ON t (a);")},
+				"10_add_index.down.sql":   {Data: []byte("DROP INDEX a;")},
+				"2_add_column.up.sql":     {Data: []byte("ALTER TABLE t ADD COLUMN a INT;")},
+				"000001_initial.up.sql":   {Data: []byte("CREATE TABLE t (id INT);")},
+				"000001_initial.down.sql": {Data: []byte("DROP TABLE t;")},
+			},
+			wantVersions: []uint64{1, 2, 10},
+			wantNames:    []string{"initial", "add_column", "add_index"},
+		},
+		{
+			name: "other files are ignored",
+			files: fstest.MapFS{
+				"000001_initial.up.sql":      {Data: []byte("CREATE TABLE t (id INT);")},
+				"README.md":                  {Data: []byte("notes")},
+				"000002_Bad-Name.up.sql":     {Data: []byte("SELECT 1;")},
+				"000003_sub/000003_x.up.sql": {Data: []byte("SELECT 1;")},
+			},
+			wantVersions: []uint64{1},
+			wantNames:    []string{"initial"},
+		},
+		{
+			name: "down file without up file",
+			files: fstest.MapFS{
+				"000001_initial.down.sql": {Data: []byte("DROP TABLE t;")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "version used by two names",
+			files: fstest.MapFS{
+				"000001_initial.up.sql": {Data: []byte("CREATE TABLE t (id INT);")},
+				"000001_other.up.sql":   {Data: []byte("CREATE TABLE u (id INT);")},
+			},
+			wantErr: true,
+		},
+		{
+			name:         "empty",
+			files:        fstest.MapFS{},
+			wantVersions: []uint64{},
+			wantNames:    []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrations, err := Load(tt.files)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if len(migrations) != len(tt.wantVersions) {
+				t.Fatalf("Load() returned %d migrations, want %d", len(migrations), len(tt.wantVersions))
+			}
+
+			for i, migration := range migrations {
+				if migration.Version != tt.wantVersions[i] || migration.Name != tt.wantNames[i] {
+					t.Errorf("migration %d = %d_%s, want %d_%s",
+						i, migration.Version, migration.Name, tt.wantVersions[i], tt.wantNames[i])
+				}
+				if migration.Up == "" {
+					t.Errorf("migration %d_%s has no up script", migration.Version, migration.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadUpAndDown(t *testing.T) {
+	files := fstest.MapFS{
+		"000001_initial.up.sql":   {Data: []byte("CREATE TABLE t (id INT);")},
+		"000001_initial.down.sql": {Data: []byte("DROP TABLE t;")},
+		"000002_seed.up.sql":      {Data: []byte("INSERT INTO t VALUES (1);")},
+	}
+
+	migrations, err := Load(files)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		got      string
+		expected string
+	}{
+		{name: "first up", got: migrations[0].Up, expected: "CREATE TABLE t (id INT);"},
+		{name: "first down", got: migrations[0].Down, expected: "DROP TABLE t;"},
+		{name: "second up", got: migrations[1].Up, expected: "INSERT INTO t VALUES (1);"},
+		{name: "second without down", got: migrations[1].Down, expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.expected {
+				t.Errorf("got %q, want %q", tt.got, tt.expected)
+			}
+		})
+	}
+}
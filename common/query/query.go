@@ -0,0 +1,116 @@
+package query
+
+import (
+	"cmp"
+	"field-service/common/cursor"
+	errConstants "field-service/constants/error"
+	"strings"
+)
+
+const maxSorts = 3
+
+// Sort is one sortable column of a listing. Value reads the column back from a
+// row so keyset cursors can carry it.
+type Sort[T any] struct {
+	Column string
+	Value  func(T) string
+}
+
+// Spec is the allow-list a listing sorts by. Sort names are what clients send;
+// columns never come from the request. ID breaks ties so every order is total.
+type Spec[T any] struct {
+	Sorts   map[string]Sort[T]
+	Default []string
+	ID      Sort[T]
+}
+
+// Keys turns comma separated sortColumn and sortOrder values into an ordering.
+// One order may be given for all columns, or one per column; asc is the default.
+func (s Spec[T]) Keys(sortColumn, sortOrder *string) ([]cursor.Key, error) {
+	names := s.Default
+	if sortColumn != nil && *sortColumn != "" {
+		names = strings.Split(*sortColumn, ",")
+	}
+
+	var orders []string
+	if sortOrder != nil && *sortOrder != "" {
+		orders = strings.Split(*sortOrder, ",")
+	}
+
+	if len(names) > maxSorts || (len(orders) > 1 && len(orders) != len(names)) {
+		return nil, errConstants.ErrInvalidSort
+	}
+
+	var (
+		keys = make([]cursor.Key, 0, len(names)+1)
+		seen = make(map[string]bool, len(names))
+	)
+	for i, name := range names {
+		name = strings.TrimSpace(name)
+		column, ok := s.Sorts[name]
+		if !ok || seen[name] {
+			return nil, errConstants.ErrInvalidSort
+		}
+		seen[name] = true
+
+		order := "asc"
+		if sortColumn == nil || *sortColumn == "" {
+			order = "desc"
+		}
+		if len(orders) == 1 {
+			order = orders[0]
+		} else if len(orders) > 1 {
+			order = orders[i]
+		}
+
+		switch strings.ToLower(strings.TrimSpace(order)) {
+		case "asc":
+			keys = append(keys, cursor.Key{Column: column.Column})
+		case "desc":
+			keys = append(keys, cursor.Key{Column: column.Column, Desc: true})
+		default:
+			return nil, errConstants.ErrInvalidSort
+		}
+	}
+
+	keys = append(keys, cursor.Key{Column: s.ID.Column, Desc: keys[len(keys)-1].Desc})
+
+	return keys, nil
+}
+
+// Values reads the sort values of row in the order of keys.
+func (s Spec[T]) Values(keys []cursor.Key, row T) []string {
+	values := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if key.Column == s.ID.Column {
+			values = append(values, s.ID.Value(row))
+			continue
+		}
+
+		for _, sort := range s.Sorts {
+			if sort.Column == key.Column {
+				values = append(values, sort.Value(row))
+				break
+			}
+		}
+	}
+
+	return values
+}
+
+// CheckRange rejects a range whose lower bound is past its upper bound.
+// Either bound may be left open.
+func CheckRange[T cmp.Ordered](from, to *T) error {
+	if from != nil && to != nil && *from > *to {
+		return errConstants.ErrInvalidFilter
+	}
+
+	return nil
+}
+
+// Contains escapes a search term for a case-insensitive LIKE match anywhere in
+// the column.
+func Contains(term string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return "%" + replacer.Replace(strings.TrimSpace(term)) + "%"
+}
@@ -0,0 +1,170 @@
+package query
+
+import (
+	"errors"
+	"field-service/common/cursor"
+	errConstants "field-service/constants/error"
+	"reflect"
+	"testing"
+)
+
+type row struct {
+	ID   string
+	Name string
+	Date string
+}
+
+var spec = Spec[row]{
+	Sorts: map[string]Sort[row]{
+		"name": {Column: "t.name", Value: func(r row) string { return r.Name }},
+		"date": {Column: "t.date", Value: func(r row) string { return r.Date }},
+		"code": {Column: "t.code", Value: func(r row) string { return "" }},
+		"kind": {Column: "t.kind", Value: func(r row) string { return "" }},
+	},
+	Default: []string{"date"},
+	ID:      Sort[row]{Column: "t.id", Value: func(r row) string { return r.ID }},
+}
+
+func ptr(value string) *string {
+	return &value
+}
+
+func TestSpecKeys(t *testing.T) {
+	tests := []struct {
+		name       string
+		sortColumn *string
+		sortOrder  *string
+		want       []cursor.Key
+		wantErr    error
+	}{
+		{
+			name: "default is newest first",
+			want: []cursor.Key{{Column: "t.date", Desc: true}, {Column: "t.id", Desc: true}},
+		},
+		{
+			name:       "empty column uses the default",
+			sortColumn: ptr(""),
+			sortOrder:  ptr("asc"),
+			want:       []cursor.Key{{Column: "t.date"}, {Column: "t.id"}},
+		},
+		{
+			name:       "ascending by default",
+			sortColumn: ptr("name"),
+			want:       []cursor.Key{{Column: "t.name"}, {Column: "t.id"}},
+		},
+		{
+			name:       "one order for all columns",
+			sortColumn: ptr("name,date"),
+			sortOrder:  ptr("DESC"),
+			want:       []cursor.Key{{Column: "t.name", Desc: true}, {Column: "t.date", Desc: true}, {Column: "t.id", Desc: true}},
+		},
+		{
+			name:       "one order per column",
+			sortColumn: ptr("name, date"),
+			sortOrder:  ptr("asc, desc"),
+			want:       []cursor.Key{{Column: "t.name"}, {Column: "t.date", Desc: true}, {Column: "t.id", Desc: true}},
+		},
+		{
+			name:       "unknown column",
+			sortColumn: ptr("password"),
+			wantErr:    errConstants.ErrInvalidSort,
+		},
+		{
+			name:       "raw sql is not a column",
+			sortColumn: ptr("t.name; drop table t"),
+			wantErr:    errConstants.ErrInvalidSort,
+		},
+		{
+			name:       "repeated column",
+			sortColumn: ptr("name,name"),
+			wantErr:    errConstants.ErrInvalidSort,
+		},
+		{
+			name:       "too many columns",
+			sortColumn: ptr("name,date,code,kind"),
+			wantErr:    errConstants.ErrInvalidSort,
+		},
+		{
+			name:       "order count does not match",
+			sortColumn: ptr("name,date,code"),
+			sortOrder:  ptr("asc,desc"),
+			wantErr:    errConstants.ErrInvalidSort,
+		},
+		{
+			name:       "unknown order",
+			sortColumn: ptr("name"),
+			sortOrder:  ptr("sideways"),
+			wantErr:    errConstants.ErrInvalidSort,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := spec.Keys(tt.sortColumn, tt.sortOrder)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Keys() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Keys() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpecValues(t *testing.T) {
+	keys := []cursor.Key{{Column: "t.name"}, {Column: "t.date", Desc: true}, {Column: "t.id", Desc: true}}
+	got := spec.Values(keys, row{ID: "7", Name: "Arena", Date: "2024-01-02"})
+	want := []string{"Arena", "2024-01-02", "7"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Values() = %v, want %v", got, want)
+	}
+}
+
+func TestContains(t *testing.T) {
+	tests := []struct {
+		term string
+		want string
+	}{
+		{term: "arena", want: "%arena%"},
+		{term: "  arena  ", want: "%arena%"},
+		{term: "100%", want: `%100\%%`},
+		{term: "a_b", want: `%a\_b%`},
+		{term: `a\b`, want: `%a\\b%`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.term, func(t *testing.T) {
+			got := Contains(tt.term)
+			if got != tt.want {
+				t.Errorf("Contains(%q) = %q, want %q", tt.term, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckRange(t *testing.T) {
+	low, high := 1, 2
+	tests := []struct {
+		name    string
+		from    *int
+		to      *int
+		wantErr error
+	}{
+		{name: "both open"},
+		{name: "lower bound only", from: &high},
+		{name: "upper bound only", to: &low},
+		{name: "ordered", from: &low, to: &high},
+		{name: "equal", from: &low, to: &low},
+		{name: "reversed", from: &high, to: &low, wantErr: errConstants.ErrInvalidFilter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckRange(tt.from, tt.to)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CheckRange() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
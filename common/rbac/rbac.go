@@ -0,0 +1,83 @@
+package rbac
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	wildcard          = "*"
+	defaultPolicyFile = "policy.json"
+)
+
+var Policy IPolicy
+
+type PolicyFile struct {
+	Roles map[string][]string `json:"roles"`
+}
+
+type RolePolicy struct {
+	permissions map[string][]string
+}
+
+type IPolicy interface {
+	HasPermission(role string, permission string) bool
+	Permissions(role string) []string
+}
+
+func NewPolicy(roles map[string][]string) IPolicy {
+	return &RolePolicy{permissions: roles}
+}
+
+func LoadPolicy(filename string) (IPolicy, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		logrus.Errorf("failed to read policy file: %v", err)
+		return nil, err
+	}
+
+	var file PolicyFile
+	err = json.Unmarshal(data, &file)
+	if err != nil {
+		logrus.Errorf("failed to parse policy file: %v", err)
+		return nil, err
+	}
+
+	return NewPolicy(file.Roles), nil
+}
+
+func Init(filename string) {
+	if filename == "" {
+		filename = defaultPolicyFile
+	}
+
+	policy, err := LoadPolicy(filename)
+	if err != nil {
+		panic(err)
+	}
+
+	Policy = policy
+}
+
+func (r *RolePolicy) Permissions(role string) []string {
+	return r.permissions[role]
+}
+
+// HasPermission accepts exact grants, "*" and resource wildcards such as "schedule:*".
+func (r *RolePolicy) HasPermission(role string, permission string) bool {
+	for _, granted := range r.permissions[role] {
+		if granted == wildcard || granted == permission {
+			return true
+		}
+
+		if strings.HasSuffix(granted, ":"+wildcard) &&
+			strings.HasPrefix(permission, strings.TrimSuffix(granted, wildcard)) {
+			return true
+		}
+	}
+
+	return false
+}
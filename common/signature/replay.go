@@ -0,0 +1,53 @@
+package signature
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// ReplayStore remembers accepted signatures until they fall outside the
+// allowed clock-skew window, after which the timestamp check rejects them
+// anyway. Remember reports false when the key is already remembered. Share
+// one store between instances, or a request replayed against another instance
+// is accepted.
+type ReplayStore interface {
+	Remember(ctx context.Context, key string, expiresAt time.Time) (bool, error)
+}
+
+// replayCache is the in-process ReplayStore used when none is configured.
+type replayCache struct {
+	mutex       sync.Mutex
+	entries     map[string]time.Time
+	lastCleanup time.Time
+}
+
+func newReplayCache() *replayCache {
+	return &replayCache{
+		entries:     make(map[string]time.Time),
+		lastCleanup: time.Now(),
+	}
+}
+
+func (r *replayCache) Remember(_ context.Context, key string, expiresAt time.Time) (bool, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	now := time.Now()
+	if now.Sub(r.lastCleanup) > time.Minute {
+		for key, expiry := range r.entries {
+			if now.After(expiry) {
+				delete(r.entries, key)
+			}
+		}
+		r.lastCleanup = now
+	}
+
+	expiry, ok := r.entries[key]
+	if ok && now.Before(expiry) {
+		return false, nil
+	}
+
+	r.entries[key] = expiresAt
+	return true, nil
+}
@@ -0,0 +1,192 @@
+package signature
+
+import (
+	"context"
+	"crypto/subtle"
+	"field-service/common/util"
+	errConstants "field-service/constants/error"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultClockSkew = 5 * time.Minute
+	wildcard         = "*"
+)
+
+type Service struct {
+	Name          string
+	SignatureKeys []string
+	AllowedRoutes []string
+	Revoked       bool
+}
+
+type Request struct {
+	ServiceName string
+	APIKey      string
+	RequestAt   string
+	Method      string
+	Route       string
+	// Nonce is the caller's request ID. When set it is part of the signed
+	// material, so calls made within the same second get distinct signatures.
+	Nonce string
+	// ReplayScope distinguishes requests sharing one signature; empty skips the replay check.
+	ReplayScope string
+}
+
+type Verifier struct {
+	services  map[string]Service
+	legacyKey string
+	clockSkew time.Duration
+	replay    ReplayStore
+}
+
+type IVerifier interface {
+	Verify(context.Context, Request) (*Service, error)
+}
+
+// NewVerifier builds a verifier for the given calling services. When no
+// services are registered, every caller is checked against legacyKey. Without
+// a replay store, accepted signatures are only remembered by this process.
+func NewVerifier(services []Service, legacyKey string, clockSkew time.Duration, replay ReplayStore) IVerifier {
+	if clockSkew <= 0 {
+		clockSkew = defaultClockSkew
+	}
+
+	if replay == nil {
+		logrus.Warn("no shared replay store configured, replayed signatures are only detected per instance")
+		replay = newReplayCache()
+	}
+
+	registry := make(map[string]Service, len(services))
+	for _, service := range services {
+		registry[service.Name] = service
+	}
+
+	if len(registry) == 0 {
+		logrus.Warn("no calling services registered, falling back to the shared signature key")
+	}
+
+	return &Verifier{
+		services:  registry,
+		legacyKey: legacyKey,
+		clockSkew: clockSkew,
+		replay:    replay,
+	}
+}
+
+func (v *Verifier) lookup(name string) (*Service, error) {
+	if len(v.services) == 0 {
+		return &Service{
+			Name:          name,
+			SignatureKeys: []string{v.legacyKey},
+			AllowedRoutes: []string{wildcard},
+		}, nil
+	}
+
+	service, ok := v.services[name]
+	if !ok {
+		logrus.Warnf("request from unknown service %q", name)
+		return nil, errConstants.ErrUnauthorized
+	}
+
+	if service.Revoked {
+		logrus.Warnf("request from revoked service %q", name)
+		return nil, errConstants.ErrUnauthorized
+	}
+
+	return &service, nil
+}
+
+// sign computes the api key for one signature key. Callers that do not send a
+// nonce yet are checked against the original serviceName:key:requestAt form.
+func sign(request Request, key string) string {
+	if request.Nonce == "" {
+		return util.GenerateSHA256(fmt.Sprintf("%s:%s:%s", request.ServiceName, key, request.RequestAt))
+	}
+
+	return util.GenerateSHA256(fmt.Sprintf("%s:%s:%s:%s", request.ServiceName, key, request.RequestAt, request.Nonce))
+}
+
+func (v *Verifier) Verify(ctx context.Context, request Request) (*Service, error) {
+	service, err := v.lookup(request.ServiceName)
+	if err != nil {
+		return nil, err
+	}
+
+	unixTime, err := strconv.ParseInt(request.RequestAt, 10, 64)
+	if err != nil {
+		logrus.Warnf("invalid request time from service %q", request.ServiceName)
+		return nil, errConstants.ErrUnauthorized
+	}
+
+	requestTime := time.Unix(unixTime, 0)
+	if time.Since(requestTime).Abs() > v.clockSkew {
+		logrus.Warnf("request signature from service %q is outside the allowed time window", request.ServiceName)
+		return nil, errConstants.ErrRequestExpired
+	}
+
+	var signature string
+	for _, key := range service.SignatureKeys {
+		expected := sign(request, key)
+		if subtle.ConstantTimeCompare([]byte(request.APIKey), []byte(expected)) == 1 {
+			signature = expected
+			break
+		}
+	}
+
+	if signature == "" {
+		logrus.Warnf("invalid api key from service %q", request.ServiceName)
+		return nil, errConstants.ErrUnauthorized
+	}
+
+	if !service.allows(request.Method, request.Route) {
+		logrus.Warnf("service %q is not allowed to call %s %s", request.ServiceName, request.Method, request.Route)
+		return nil, errConstants.ErrForbiden
+	}
+
+	if request.ReplayScope != "" {
+		replayKey := util.GenerateSHA256(fmt.Sprintf("%s:%s", signature, request.ReplayScope))
+		remembered, err := v.replay.Remember(ctx, replayKey, requestTime.Add(v.clockSkew))
+		if err != nil {
+			logrus.Errorf("failed to check request signature replay: %v", err)
+			return nil, errConstants.ErrInternalServer
+		}
+
+		if !remembered {
+			logrus.Warnf("replayed api key from service %q", request.ServiceName)
+			return nil, errConstants.ErrRequestReplayed
+		}
+	}
+
+	return service, nil
+}
+
+// allows matches "METHOD /path" entries, where either part may be "*" and a
+// path ending in "*" matches by prefix.
+func (s *Service) allows(method, route string) bool {
+	for _, allowed := range s.AllowedRoutes {
+		allowedMethod, allowedPath := wildcard, allowed
+		if parts := strings.Fields(allowed); len(parts) == 2 {
+			allowedMethod, allowedPath = parts[0], parts[1]
+		}
+
+		if allowedMethod != wildcard && !strings.EqualFold(allowedMethod, method) {
+			continue
+		}
+
+		if allowedPath == wildcard || allowedPath == route {
+			return true
+		}
+
+		if strings.HasSuffix(allowedPath, wildcard) && strings.HasPrefix(route, strings.TrimSuffix(allowedPath, wildcard)) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,298 @@
+package signature
+
+import (
+	"context"
+	"errors"
+	"field-service/common/util"
+	errConstants "field-service/constants/error"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+)
+
+type failingStore struct{}
+
+func (failingStore) Remember(context.Context, string, time.Time) (bool, error) {
+	return false, errors.New("store unavailable")
+}
+
+func apiKey(serviceName, key, requestAt, nonce string) string {
+	if nonce == "" {
+		return util.GenerateSHA256(fmt.Sprintf("%s:%s:%s", serviceName, key, requestAt))
+	}
+
+	return util.GenerateSHA256(fmt.Sprintf("%s:%s:%s:%s", serviceName, key, requestAt, nonce))
+}
+
+func TestVerify(t *testing.T) {
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	stale := strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10)
+	services := []Service{
+		{
+			Name:          "order-service",
+			SignatureKeys: []string{"new-key", "old-key"},
+			AllowedRoutes: []string{"PATCH /api/v1/field/schedule/status", "GET /api/v1/field*"},
+		},
+		{
+			Name:          "revoked-service",
+			SignatureKeys: []string{"key"},
+			AllowedRoutes: []string{"*"},
+			Revoked:       true,
+		},
+	}
+
+	tests := []struct {
+		name    string
+		request Request
+		wantErr error
+	}{
+		{
+			name: "signed with nonce",
+			request: Request{
+				ServiceName: "order-service",
+				APIKey:      apiKey("order-service", "new-key", now, "request-1"),
+				RequestAt:   now,
+				Method:      "PATCH",
+				Route:       "/api/v1/field/schedule/status",
+				Nonce:       "request-1",
+			},
+		},
+		{
+			name: "signed without nonce",
+			request: Request{
+				ServiceName: "order-service",
+				APIKey:      apiKey("order-service", "new-key", now, ""),
+				RequestAt:   now,
+				Method:      "GET",
+				Route:       "/api/v1/field/:uuid",
+			},
+		},
+		{
+			name: "signed with a previous key",
+			request: Request{
+				ServiceName: "order-service",
+				APIKey:      apiKey("order-service", "old-key", now, ""),
+				RequestAt:   now,
+				Method:      "GET",
+				Route:       "/api/v1/field",
+			},
+		},
+		{
+			name: "nonce changed after signing",
+			request: Request{
+				ServiceName: "order-service",
+				APIKey:      apiKey("order-service", "new-key", now, "request-1"),
+				RequestAt:   now,
+				Method:      "PATCH",
+				Route:       "/api/v1/field/schedule/status",
+				Nonce:       "request-2",
+			},
+			wantErr: errConstants.ErrUnauthorized,
+		},
+		{
+			name: "nonce stripped after signing",
+			request: Request{
+				ServiceName: "order-service",
+				APIKey:      apiKey("order-service", "new-key", now, "request-1"),
+				RequestAt:   now,
+				Method:      "PATCH",
+				Route:       "/api/v1/field/schedule/status",
+			},
+			wantErr: errConstants.ErrUnauthorized,
+		},
+		{
+			name: "wrong key",
+			request: Request{
+				ServiceName: "order-service",
+				APIKey:      apiKey("order-service", "other-key", now, ""),
+				RequestAt:   now,
+				Method:      "GET",
+				Route:       "/api/v1/field",
+			},
+			wantErr: errConstants.ErrUnauthorized,
+		},
+		{
+			name: "unknown service",
+			request: Request{
+				ServiceName: "payment-service",
+				APIKey:      apiKey("payment-service", "new-key", now, ""),
+				RequestAt:   now,
+				Method:      "GET",
+				Route:       "/api/v1/field",
+			},
+			wantErr: errConstants.ErrUnauthorized,
+		},
+		{
+			name: "revoked service",
+			request: Request{
+				ServiceName: "revoked-service",
+				APIKey:      apiKey("revoked-service", "key", now, ""),
+				RequestAt:   now,
+				Method:      "GET",
+				Route:       "/api/v1/field",
+			},
+			wantErr: errConstants.ErrUnauthorized,
+		},
+		{
+			name: "invalid request time",
+			request: Request{
+				ServiceName: "order-service",
+				APIKey:      apiKey("order-service", "new-key", "now", ""),
+				RequestAt:   "now",
+				Method:      "GET",
+				Route:       "/api/v1/field",
+			},
+			wantErr: errConstants.ErrUnauthorized,
+		},
+		{
+			name: "outside the clock skew",
+			request: Request{
+				ServiceName: "order-service",
+				APIKey:      apiKey("order-service", "new-key", stale, ""),
+				RequestAt:   stale,
+				Method:      "GET",
+				Route:       "/api/v1/field",
+			},
+			wantErr: errConstants.ErrRequestExpired,
+		},
+		{
+			name: "route not allowed",
+			request: Request{
+				ServiceName: "order-service",
+				APIKey:      apiKey("order-service", "new-key", now, ""),
+				RequestAt:   now,
+				Method:      "DELETE",
+				Route:       "/api/v1/field/:uuid",
+			},
+			wantErr: errConstants.ErrForbiden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier := NewVerifier(services, "", time.Minute, newReplayCache())
+			service, err := verifier.Verify(context.Background(), tt.request)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr == nil && service.Name != tt.request.ServiceName {
+				t.Errorf("Verify() service = %q, want %q", service.Name, tt.request.ServiceName)
+			}
+		})
+	}
+}
+
+func TestVerifyReplay(t *testing.T) {
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	services := []Service{{
+		Name:          "order-service",
+		SignatureKeys: []string{"key"},
+		AllowedRoutes: []string{"*"},
+	}}
+	request := func(nonce, scope string) Request {
+		return Request{
+			ServiceName: "order-service",
+			APIKey:      apiKey("order-service", "key", now, nonce),
+			RequestAt:   now,
+			Method:      "PATCH",
+			Route:       "/api/v1/field/schedule/status",
+			Nonce:       nonce,
+			ReplayScope: scope,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		store    ReplayStore
+		first    Request
+		second   Request
+		wantErr  error
+		firstErr error
+	}{
+		{
+			name:    "same request is replayed",
+			first:   request("request-1", "PATCH:/status"),
+			second:  request("request-1", "PATCH:/status"),
+			wantErr: errConstants.ErrRequestReplayed,
+		},
+		{
+			name:   "distinct nonces within one second",
+			first:  request("request-1", "PATCH:/status"),
+			second: request("request-2", "PATCH:/status"),
+		},
+		{
+			name:    "no nonce within one second",
+			first:   request("", "PATCH:/status"),
+			second:  request("", "PATCH:/status"),
+			wantErr: errConstants.ErrRequestReplayed,
+		},
+		{
+			name:   "no nonce with another scope",
+			first:  request("", "PATCH:/status"),
+			second: request("", "PUT:/schedule"),
+		},
+		{
+			name:   "replay check skipped without scope",
+			first:  request("request-1", ""),
+			second: request("request-1", ""),
+		},
+		{
+			name:     "store failure",
+			store:    failingStore{},
+			first:    request("request-1", "PATCH:/status"),
+			firstErr: errConstants.ErrInternalServer,
+			second:   request("request-1", ""),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := tt.store
+			if store == nil {
+				store = newReplayCache()
+			}
+
+			verifier := NewVerifier(services, "", time.Minute, store)
+			_, err := verifier.Verify(context.Background(), tt.first)
+			if !errors.Is(err, tt.firstErr) {
+				t.Fatalf("first Verify() error = %v, want %v", err, tt.firstErr)
+			}
+
+			_, err = verifier.Verify(context.Background(), tt.second)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("second Verify() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifyReplaySharedStore(t *testing.T) {
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	services := []Service{{
+		Name:          "order-service",
+		SignatureKeys: []string{"key"},
+		AllowedRoutes: []string{"*"},
+	}}
+	request := Request{
+		ServiceName: "order-service",
+		APIKey:      apiKey("order-service", "key", now, "request-1"),
+		RequestAt:   now,
+		Method:      "PATCH",
+		Route:       "/api/v1/field/schedule/status",
+		Nonce:       "request-1",
+		ReplayScope: "PATCH:/status",
+	}
+
+	store := newReplayCache()
+	_, err := NewVerifier(services, "", time.Minute, store).Verify(context.Background(), request)
+	if err != nil {
+		t.Fatalf("Verify() on the first instance error = %v", err)
+	}
+
+	_, err = NewVerifier(services, "", time.Minute, store).Verify(context.Background(), request)
+	if !errors.Is(err, errConstants.ErrRequestReplayed) {
+		t.Errorf("Verify() on the second instance error = %v, want %v", err, errConstants.ErrRequestReplayed)
+	}
+}
@@ -0,0 +1,314 @@
+package token
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	ErrNoVerificationKey = errors.New("no jwt verification key configured")
+	ErrUnknownKeyID      = errors.New("unknown jwt key id")
+	ErrMissingClaim      = errors.New("missing jwt claim")
+)
+
+type Config struct {
+	HMACSecret    string
+	PublicKeyFile string
+	JWKSFile      string
+	Issuer        string
+	Audience      string
+	UUIDClaim     string
+	RoleClaim     string
+}
+
+type Claims struct {
+	UUID uuid.UUID
+	Role string
+}
+
+type TokenVerifier struct {
+	hmacSecret []byte
+	publicKey  any
+	jwks       map[string]any
+	issuer     string
+	audience   string
+	uuidClaim  string
+	roleClaim  string
+}
+
+type ITokenVerifier interface {
+	Verify(string) (*Claims, error)
+}
+
+func NewTokenVerifier(config Config) (ITokenVerifier, error) {
+	verifier := &TokenVerifier{
+		issuer:    config.Issuer,
+		audience:  config.Audience,
+		uuidClaim: config.UUIDClaim,
+		roleClaim: config.RoleClaim,
+	}
+
+	if verifier.uuidClaim == "" {
+		verifier.uuidClaim = "uuid"
+	}
+
+	if verifier.roleClaim == "" {
+		verifier.roleClaim = "role"
+	}
+
+	if config.HMACSecret != "" {
+		verifier.hmacSecret = []byte(config.HMACSecret)
+	}
+
+	if config.PublicKeyFile != "" {
+		publicKey, err := loadPublicKey(config.PublicKeyFile)
+		if err != nil {
+			logrus.Errorf("failed to load jwt public key: %v", err)
+			return nil, err
+		}
+		verifier.publicKey = publicKey
+	}
+
+	if config.JWKSFile != "" {
+		jwks, err := loadJWKS(config.JWKSFile)
+		if err != nil {
+			logrus.Errorf("failed to load jwks: %v", err)
+			return nil, err
+		}
+		verifier.jwks = jwks
+	}
+
+	if verifier.hmacSecret == nil && verifier.publicKey == nil && len(verifier.jwks) == 0 {
+		return nil, ErrNoVerificationKey
+	}
+
+	return verifier, nil
+}
+
+func (t *TokenVerifier) keyFunc(token *jwt.Token) (any, error) {
+	switch token.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		if t.hmacSecret == nil {
+			return nil, ErrNoVerificationKey
+		}
+		return t.hmacSecret, nil
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS, *jwt.SigningMethodECDSA:
+		if kid, ok := token.Header["kid"].(string); ok && len(t.jwks) > 0 {
+			key, ok := t.jwks[kid]
+			if !ok {
+				return nil, ErrUnknownKeyID
+			}
+			return key, nil
+		}
+
+		if t.publicKey != nil {
+			return t.publicKey, nil
+		}
+
+		if len(t.jwks) == 1 {
+			for _, key := range t.jwks {
+				return key, nil
+			}
+		}
+
+		return nil, ErrNoVerificationKey
+	default:
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+}
+
+func (t *TokenVerifier) Verify(tokenString string) (*Claims, error) {
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{
+			"HS256", "HS384", "HS512",
+			"RS256", "RS384", "RS512",
+			"PS256", "PS384", "PS512",
+			"ES256", "ES384", "ES512",
+		}),
+		jwt.WithExpirationRequired(),
+	}
+
+	if t.issuer != "" {
+		options = append(options, jwt.WithIssuer(t.issuer))
+	}
+
+	if t.audience != "" {
+		options = append(options, jwt.WithAudience(t.audience))
+	}
+
+	mapClaims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, mapClaims, t.keyFunc, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	rawUUID, ok := lookupClaim(mapClaims, t.uuidClaim).(string)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrMissingClaim, t.uuidClaim)
+	}
+
+	userUUID, err := uuid.Parse(rawUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	role, ok := lookupClaim(mapClaims, t.roleClaim).(string)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrMissingClaim, t.roleClaim)
+	}
+
+	return &Claims{
+		UUID: userUUID,
+		Role: role,
+	}, nil
+}
+
+// lookupClaim resolves a dotted path such as "user.uuid" against nested claims.
+func lookupClaim(claims map[string]any, path string) any {
+	var current any = claims
+	for _, key := range strings.Split(path, ".") {
+		object, ok := current.(map[string]any)
+		if !ok {
+			return nil
+		}
+		current = object[key]
+	}
+
+	return current
+}
+
+func loadPublicKey(filename string) (any, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("invalid pem public key")
+	}
+
+	if block.Type == "CERTIFICATE" {
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return certificate.PublicKey, nil
+	}
+
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	}
+
+	return publicKey, nil
+}
+
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+func loadJWKS(filename string) (map[string]any, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var set jsonWebKeySet
+	err = json.Unmarshal(data, &set)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]any, len(set.Keys))
+	for _, key := range set.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+
+		publicKey, err := key.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("jwk %s: %w", key.Kid, err)
+		}
+		keys[key.Kid] = publicKey
+	}
+
+	return keys, nil
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	bytes, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(bytes), nil
+}
+
+func (k jsonWebKey) publicKey() (any, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %s", k.Crv)
+		}
+
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %s", k.Kty)
+	}
+}
@@ -0,0 +1,85 @@
+package tracing
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const gormSpanKey = "tracing:span"
+
+// GormPlugin starts a client span around every gorm operation, parented to
+// the span in the statement context.
+type GormPlugin struct{}
+
+func NewGormPlugin() gorm.Plugin {
+	return &GormPlugin{}
+}
+
+func (g *GormPlugin) Name() string {
+	return "tracing"
+}
+
+func (g *GormPlugin) Initialize(db *gorm.DB) error {
+	callback := db.Callback()
+	return errors.Join(
+		callback.Create().Before("gorm:create").Register("tracing:before_create", g.before("create")),
+		callback.Create().After("gorm:create").Register("tracing:after_create", g.after),
+		callback.Query().Before("gorm:query").Register("tracing:before_query", g.before("query")),
+		callback.Query().After("gorm:query").Register("tracing:after_query", g.after),
+		callback.Update().Before("gorm:update").Register("tracing:before_update", g.before("update")),
+		callback.Update().After("gorm:update").Register("tracing:after_update", g.after),
+		callback.Delete().Before("gorm:delete").Register("tracing:before_delete", g.before("delete")),
+		callback.Delete().After("gorm:delete").Register("tracing:after_delete", g.after),
+		callback.Row().Before("gorm:row").Register("tracing:before_row", g.before("row")),
+		callback.Row().After("gorm:row").Register("tracing:after_row", g.after),
+		callback.Raw().Before("gorm:raw").Register("tracing:before_raw", g.before("raw")),
+		callback.Raw().After("gorm:raw").Register("tracing:after_raw", g.after),
+	)
+}
+
+func (g *GormPlugin) before(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		if db.Statement == nil || db.Statement.Context == nil {
+			return
+		}
+
+		ctx, span := Tracer().Start(db.Statement.Context, "gorm."+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				semconv.DBSystemPostgreSQL,
+				semconv.DBOperationName(operation),
+			),
+		)
+		db.Statement.Context = ctx
+		db.InstanceSet(gormSpanKey, span)
+	}
+}
+
+func (g *GormPlugin) after(db *gorm.DB) {
+	value, ok := db.InstanceGet(gormSpanKey)
+	if !ok {
+		return
+	}
+
+	span, ok := value.(trace.Span)
+	if !ok {
+		return
+	}
+	defer span.End()
+
+	span.SetAttributes(
+		semconv.DBCollectionName(db.Statement.Table),
+		semconv.DBQueryText(db.Statement.SQL.String()),
+		attribute.Int64("db.rows_affected", db.Statement.RowsAffected),
+	)
+
+	if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		span.RecordError(db.Error)
+		span.SetStatus(codes.Error, db.Error.Error())
+	}
+}
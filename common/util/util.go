@@ -9,11 +9,13 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/dustin/go-humanize"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 	_ "github.com/spf13/viper/remote"
+	"gorm.io/gorm"
 )
 
 type PaginationParam struct {
@@ -33,6 +35,15 @@ type PaginationResult struct {
 	Data         interface{} `json:"data"`
 }
 
+// CursorPaginationResult is a keyset page. NextCursor is nil on the last page and
+// TotalData is only filled when the caller asked for the count.
+type CursorPaginationResult struct {
+	NextCursor *string     `json:"nextCursor"`
+	Limit      int         `json:"limit"`
+	TotalData  *int64      `json:"totalData,omitempty"`
+	Data       interface{} `json:"data"`
+}
+
 func GeneratePagination(params PaginationParam) PaginationResult {
 	totalPage := int(math.Ceil(float64(params.Count) / float64(params.Limit)))
 
@@ -61,6 +72,15 @@ func GeneratePagination(params PaginationParam) PaginationResult {
 	return result
 }
 
+// DeletedAtTime returns when a soft-deleted row was trashed, or nil for a live row.
+func DeletedAtTime(deletedAt *gorm.DeletedAt) *time.Time {
+	if deletedAt == nil || !deletedAt.Valid {
+		return nil
+	}
+
+	return &deletedAt.Time
+}
+
 func GenerateSHA256(inputString string) string {
 	hash := sha256.New()
 	hash.Write([]byte(inputString))
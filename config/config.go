@@ -10,26 +10,80 @@ import (
 var Config AppConfig
 
 type AppConfig struct {
-	Port                       int             `json:"port"`
-	AppName                    string          `json:"appName"`
-	AppEnv                     string          `json:"appEnv"`
-	SignatureKey               string          `json:"signatureKey"`
-	Database                   Database        `json:"database"`
-	RateLimiterMaxRequest      float64         `json:"rateLimiterMaxRequest"`
-	RateLimiterTimeSecond      int             `json:"rateLimiterTimeSecond"`
-	InternalService            InternalService `json:"internalService"`
-	GCSType                    string          `json:"gcsType"`
-	GCSProjectID               string          `json:"gcsProjectID"`
-	GCSPrivateKeyID            string          `json:"gcsPrivateKeyID"`
-	GCSPrivateKey              string          `json:"gcsPrivateKey"`
-	GCSClientEmail             string          `json:"gcsClientEmail"`
-	GCSClientID                string          `json:"gcsClientID"`
-	GCSAuthURI                 string          `json:"gcsAuthURI"`
-	GCSTokenURI                string          `json:"gcsTokenURI"`
-	GCSAuthProviderX509CertURL string          `json:"gcsAuthProviderX509CertURL"`
-	GCSClientX509CertURL       string          `json:"gcsClientX509CertURL"`
-	GCSUniverseDomain          string          `json:"gcsUniverseDomain"`
-	GCSBucketName              string          `json:"gcsBucketName"`
+	Port                       int              `json:"port"`
+	GRPCPort                   int              `json:"grpcPort"`
+	Server                     Server           `json:"server"`
+	Log                        Log              `json:"log"`
+	Health                     Health           `json:"health"`
+	Tracing                    Tracing          `json:"tracing"`
+	AppName                    string           `json:"appName"`
+	AppEnv                     string           `json:"appEnv"`
+	SignatureKey               string           `json:"signatureKey" secret:"true"`
+	SignatureClockSkewSecond   int              `json:"signatureClockSkewSecond"`
+	SignatureReplay            SignatureReplay  `json:"signatureReplay"`
+	CallingServices            []CallingService `json:"callingServices"`
+	PolicyFile                 string           `json:"policyFile"`
+	CORS                       map[string]CORS  `json:"cors"`
+	Webhook                    Webhook          `json:"webhook"`
+	Idempotency                Idempotency      `json:"idempotency"`
+	Broker                     Broker           `json:"broker"`
+	Outbox                     Outbox           `json:"outbox"`
+	OrderConsumer              OrderConsumer    `json:"orderConsumer"`
+	JWT                        JWT              `json:"jwt"`
+	Database                   Database         `json:"database"`
+	Migration                  Migration        `json:"migration"`
+	Trash                      Trash            `json:"trash"`
+	Pagination                 Pagination       `json:"pagination"`
+	RateLimiterMaxRequest      float64          `json:"rateLimiterMaxRequest"`
+	RateLimiterTimeSecond      int              `json:"rateLimiterTimeSecond"`
+	InternalService            InternalService  `json:"internalService"`
+	GCSType                    string           `json:"gcsType"`
+	GCSProjectID               string           `json:"gcsProjectID"`
+	GCSPrivateKeyID            string           `json:"gcsPrivateKeyID" secret:"true"`
+	GCSPrivateKey              string           `json:"gcsPrivateKey" secret:"true"`
+	GCSClientEmail             string           `json:"gcsClientEmail"`
+	GCSClientID                string           `json:"gcsClientID"`
+	GCSAuthURI                 string           `json:"gcsAuthURI"`
+	GCSTokenURI                string           `json:"gcsTokenURI"`
+	GCSAuthProviderX509CertURL string           `json:"gcsAuthProviderX509CertURL"`
+	GCSClientX509CertURL       string           `json:"gcsClientX509CertURL"`
+	GCSUniverseDomain          string           `json:"gcsUniverseDomain"`
+	GCSBucketName              string           `json:"gcsBucketName"`
+}
+
+type Server struct {
+	ReadHeaderTimeoutSecond int `json:"readHeaderTimeoutSecond"`
+	ReadTimeoutSecond       int `json:"readTimeoutSecond"`
+	WriteTimeoutSecond      int `json:"writeTimeoutSecond"`
+	IdleTimeoutSecond       int `json:"idleTimeoutSecond"`
+	MaxHeaderBytes          int `json:"maxHeaderBytes"`
+	ShutdownTimeoutSecond   int `json:"shutdownTimeoutSecond"`
+}
+
+type Log struct {
+	Level  string `json:"level"`
+	Format string `json:"format"`
+}
+
+type Health struct {
+	DatabaseTimeoutMillisecond int         `json:"databaseTimeoutMillisecond"`
+	Storage                    HealthCheck `json:"storage"`
+	UserService                HealthCheck `json:"userService"`
+}
+
+type HealthCheck struct {
+	Enabled            bool `json:"enabled"`
+	Critical           bool `json:"critical"`
+	TimeoutMillisecond int  `json:"timeoutMillisecond"`
+}
+
+type Tracing struct {
+	Exporter    string            `json:"exporter"`
+	Endpoint    string            `json:"endpoint"`
+	Insecure    bool              `json:"insecure"`
+	Headers     map[string]string `json:"headers" secret:"true"`
+	FilePath    string            `json:"filePath"`
+	SampleRatio float64           `json:"sampleRatio"`
 }
 
 type Database struct {
@@ -37,11 +91,109 @@ type Database struct {
 	Port                  int    `json:"port"`
 	Name                  string `json:"name"`
 	Username              string `json:"username"`
-	Password              string `json:"password"`
+	Password              string `json:"password" secret:"true"`
 	MaxOpenConnection     int    `json:"maxOpenConnection"`
 	MaxIdleConnection     int    `json:"maxIdleConnection"`
 	MaxLifeTimeConnection int    `json:"maxLifeTimeConnection"`
 	MaxIdleTime           int    `json:"maxIdleTime"`
+	// Replicas serve the read-only queries that opt into them. Leave empty to
+	// send everything to the primary.
+	Replicas []DatabaseReplica `json:"replicas"`
+}
+
+// DatabaseReplica falls back to the primary's name and credentials for empty fields.
+type DatabaseReplica struct {
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	Name     string `json:"name"`
+	Username string `json:"username"`
+	Password string `json:"password" secret:"true"`
+}
+
+type Migration struct {
+	RunOnStart        *bool `json:"runOnStart"`
+	AutoMigrate       bool  `json:"autoMigrate"`
+	LockTimeoutSecond int   `json:"lockTimeoutSecond"`
+}
+
+// ShouldRunOnStart is true unless runOnStart is explicitly false, so a config
+// without a migration block still starts on a migrated schema.
+func (m Migration) ShouldRunOnStart() bool {
+	return m.RunOnStart == nil || *m.RunOnStart
+}
+
+type Trash struct {
+	RetentionDay int `json:"retentionDay"`
+}
+
+type Pagination struct {
+	CursorSecret string `json:"cursorSecret" secret:"true"`
+}
+
+type Webhook struct {
+	MaxAttempts        int `json:"maxAttempts"`
+	RetryBaseSecond    int `json:"retryBaseSecond"`
+	PollIntervalSecond int `json:"pollIntervalSecond"`
+	TimeoutSecond      int `json:"timeoutSecond"`
+	BatchSize          int `json:"batchSize"`
+}
+
+type Idempotency struct {
+	TTLSecond             int `json:"ttlSecond"`
+	LockSecond            int `json:"lockSecond"`
+	CleanupIntervalSecond int `json:"cleanupIntervalSecond"`
+}
+
+type SignatureReplay struct {
+	CleanupIntervalSecond int `json:"cleanupIntervalSecond"`
+}
+
+type Broker struct {
+	Type          string `json:"type"`
+	NATSURL       string `json:"natsURL"`
+	SubjectPrefix string `json:"subjectPrefix"`
+	QueueGroup    string `json:"queueGroup"`
+}
+
+type Outbox struct {
+	RunInProcess            bool `json:"runInProcess"`
+	PollIntervalMillisecond int  `json:"pollIntervalMillisecond"`
+	BatchSize               int  `json:"batchSize"`
+	MaxAttempts             int  `json:"maxAttempts"`
+}
+
+type OrderConsumer struct {
+	RunInProcess            bool `json:"runInProcess"`
+	MaxAttempts             int  `json:"maxAttempts"`
+	RetryBackoffMillisecond int  `json:"retryBackoffMillisecond"`
+}
+
+type CORS struct {
+	AllowedOrigins   []string `json:"allowedOrigins"`
+	AllowedMethods   []string `json:"allowedMethods"`
+	AllowedHeaders   []string `json:"allowedHeaders"`
+	ExposedHeaders   []string `json:"exposedHeaders"`
+	AllowCredentials bool     `json:"allowCredentials"`
+	MaxAgeSecond     int      `json:"maxAgeSecond"`
+}
+
+type CallingService struct {
+	Name          string   `json:"name"`
+	SignatureKeys []string `json:"signatureKeys" secret:"true"`
+	AllowedRoutes []string `json:"allowedRoutes"`
+	Revoked       bool     `json:"revoked"`
+}
+
+type JWT struct {
+	Mode                  string `json:"mode"`
+	HMACSecret            string `json:"hmacSecret" secret:"true"`
+	PublicKeyFile         string `json:"publicKeyFile"`
+	JWKSFile              string `json:"jwksFile"`
+	Issuer                string `json:"issuer"`
+	Audience              string `json:"audience"`
+	UUIDClaim             string `json:"uuidClaim"`
+	RoleClaim             string `json:"roleClaim"`
+	FallbackToUserService bool   `json:"fallbackToUserService"`
 }
 
 type InternalService struct {
@@ -49,8 +201,16 @@ type InternalService struct {
 }
 
 type User struct {
-	Host         string `json:"host"`
-	SignatureKey string `json:"signatureKey"`
+	Host           string `json:"host"`
+	SignatureKey   string `json:"signatureKey" secret:"true"`
+	CacheTTLSecond int    `json:"cacheTTLSecond"`
+	CacheMaxSize   int    `json:"cacheMaxSize"`
+
+	TimeoutSecond               int `json:"timeoutSecond"`
+	MaxRetries                  int `json:"maxRetries"`
+	RetryBackoffMillisecond     int `json:"retryBackoffMillisecond"`
+	CircuitBreakerThreshold     int `json:"circuitBreakerThreshold"`
+	CircuitBreakerTimeoutSecond int `json:"circuitBreakerTimeoutSecond"`
 }
 
 func Init() {
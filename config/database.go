@@ -1,6 +1,8 @@
 package config
 
 import (
+	"field-service/common/tracing"
+	"field-service/constants"
 	"fmt"
 	"net/url"
 	"time"
@@ -8,14 +10,23 @@ import (
 	"github.com/sirupsen/logrus"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
 )
 
+func dsn(username, password, host string, port int, name string) string {
+	return fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=disable",
+		username,
+		url.QueryEscape(password),
+		host,
+		port,
+		name)
+}
+
 func InitDatabase() (*gorm.DB, error) {
 	config := Config
-	encodedPassword := url.QueryEscape(config.Database.Password)
-	uri := fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=disable",
+	uri := dsn(
 		config.Database.Username,
-		encodedPassword,
+		config.Database.Password,
 		config.Database.Host,
 		config.Database.Port,
 		config.Database.Name)
@@ -26,6 +37,18 @@ func InitDatabase() (*gorm.DB, error) {
 		return nil, err
 	}
 
+	err = db.Use(tracing.NewGormPlugin())
+	if err != nil {
+		logrus.Errorf("Error registering tracing plugin, %s", err)
+		return nil, err
+	}
+
+	err = useReplicas(db, config.Database)
+	if err != nil {
+		logrus.Errorf("Error registering read replicas, %s", err)
+		return nil, err
+	}
+
 	sqlDB, err := db.DB()
 	if err != nil {
 		logrus.Errorf("Error getting database instance, %s", err)
@@ -40,3 +63,42 @@ func InitDatabase() (*gorm.DB, error) {
 	return db, nil
 
 }
+
+// useReplicas registers the replicas under a named resolver rather than globally,
+// so queries only leave the primary when a repository asks for it with
+// dbresolver.Use(constants.ReadReplica). Transactions always stay on the primary.
+func useReplicas(db *gorm.DB, database Database) error {
+	if len(database.Replicas) == 0 {
+		return nil
+	}
+
+	replicas := make([]gorm.Dialector, 0, len(database.Replicas))
+	for _, replica := range database.Replicas {
+		name, username, password := replica.Name, replica.Username, replica.Password
+		if name == "" {
+			name = database.Name
+		}
+		if username == "" {
+			username = database.Username
+			password = database.Password
+		}
+
+		port := replica.Port
+		if port == 0 {
+			port = database.Port
+		}
+
+		replicas = append(replicas, postgres.Open(dsn(username, password, replica.Host, port, name)))
+	}
+
+	resolver := dbresolver.Register(dbresolver.Config{
+		Replicas: replicas,
+		Policy:   dbresolver.RandomPolicy{},
+	}, constants.ReadReplica).
+		SetMaxOpenConns(database.MaxOpenConnection).
+		SetMaxIdleConns(database.MaxIdleConnection).
+		SetConnMaxLifetime(time.Duration(database.MaxLifeTimeConnection) * time.Second).
+		SetConnMaxIdleTime(time.Duration(database.MaxIdleTime) * time.Second)
+
+	return db.Use(resolver)
+}
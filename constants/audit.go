@@ -0,0 +1,16 @@
+package constants
+
+type AuditAction string
+type AuditEntity string
+
+const (
+	AuditCreate       AuditAction = "create"
+	AuditUpdate       AuditAction = "update"
+	AuditUpdateStatus AuditAction = "update_status"
+	AuditDelete       AuditAction = "delete"
+	AuditRestore      AuditAction = "restore"
+
+	AuditField         AuditEntity = "field"
+	AuditFieldSchedule AuditEntity = "field_schedule"
+	AuditTime          AuditEntity = "time"
+)
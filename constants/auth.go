@@ -1,5 +1,19 @@
 package constants
 
 const (
-	Token = "token"
+	Token    = "token"
+	User     = "user"
+	UserUUID = "userUUID"
+
+	ServiceName = "serviceName"
+)
+
+const (
+	AuthModeRemote = "remote"
+	AuthModeLocal  = "local"
+)
+
+const (
+	RequestID = "requestID"
+	SourceIP  = "sourceIP"
 )
@@ -0,0 +1,5 @@
+package constants
+
+// ReadReplica names the resolver that read-only queries opt into. Without
+// configured replicas it is not registered and those queries use the primary.
+const ReadReplica = "replica"
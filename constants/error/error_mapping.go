@@ -3,7 +3,10 @@ package error
 import (
 	errField "field-service/constants/error/field"
 	errFieldSchedule "field-service/constants/error/field_schedule"
+	errIdempotency "field-service/constants/error/idempotency"
+	errMessage "field-service/constants/error/message"
 	errTime "field-service/constants/error/time"
+	errWebhook "field-service/constants/error/webhook"
 )
 
 func ErrMapping(err error) bool {
@@ -12,12 +15,18 @@ func ErrMapping(err error) bool {
 		FieldErrors         = errField.FieldErrors
 		FieldScheduleErrors = errFieldSchedule.FieldScheduleErrors
 		TimeErrors          = errTime.TimeErrors
+		WebhookErrors       = errWebhook.WebhookErrors
+		IdempotencyErrors   = errIdempotency.IdempotencyErrors
+		MessageErrors       = errMessage.MessageErrors
 	)
 	allErrors := make([]error, 0)
 	allErrors = append(allErrors, GeneralErrors...)
 	allErrors = append(allErrors, FieldErrors...)
 	allErrors = append(allErrors, FieldScheduleErrors...)
 	allErrors = append(allErrors, TimeErrors...)
+	allErrors = append(allErrors, WebhookErrors...)
+	allErrors = append(allErrors, IdempotencyErrors...)
+	allErrors = append(allErrors, MessageErrors...)
 
 	for _, item := range allErrors {
 		if err.Error() == item.Error() {
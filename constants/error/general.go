@@ -12,6 +12,13 @@ var (
 	ErrInvalidUploadFile = errors.New("invalid upload file")
 	ErrSizeTooBig        = errors.New("file size too big")
 	ErrForbiden          = errors.New("forbiden")
+	ErrRequestExpired    = errors.New("request signature expired")
+	ErrRequestReplayed   = errors.New("request signature already used")
+	ErrInvalidCursor     = errors.New("invalid cursor")
+	ErrInvalidSort       = errors.New("invalid sort")
+	ErrInvalidFilter     = errors.New("invalid filter")
+
+	ErrAuthUpstreamUnavailable = errors.New("auth upstream unavailable")
 )
 
 var GeneralErrors = []error{
@@ -21,4 +28,10 @@ var GeneralErrors = []error{
 	ErrNotFound,
 	ErrInvalidToken,
 	ErrForbiden,
+	ErrRequestExpired,
+	ErrRequestReplayed,
+	ErrInvalidCursor,
+	ErrInvalidSort,
+	ErrInvalidFilter,
+	ErrAuthUpstreamUnavailable,
 }
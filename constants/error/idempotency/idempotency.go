@@ -0,0 +1,15 @@
+package error
+
+import "errors"
+
+var (
+	ErrIdempotencyKeyInvalid    = errors.New("idempotency key must be between 1 and 255 characters")
+	ErrIdempotencyKeyReused     = errors.New("idempotency key was already used with a different request")
+	ErrIdempotencyKeyInProgress = errors.New("a request with this idempotency key is still being processed")
+)
+
+var IdempotencyErrors = []error{
+	ErrIdempotencyKeyInvalid,
+	ErrIdempotencyKeyReused,
+	ErrIdempotencyKeyInProgress,
+}
@@ -0,0 +1,11 @@
+package error
+
+import "errors"
+
+var (
+	ErrMessageProcessed = errors.New("message already processed")
+)
+
+var MessageErrors = []error{
+	ErrMessageProcessed,
+}
@@ -4,8 +4,10 @@ import "errors"
 
 var (
 	ErrTimeNotFound = errors.New("field not found")
+	ErrTimeExist    = errors.New("time already exist")
 )
 
 var TimeErrors = []error{
 	ErrTimeNotFound,
+	ErrTimeExist,
 }
@@ -0,0 +1,17 @@
+package error
+
+import "errors"
+
+var (
+	ErrWebhookNotFound         = errors.New("webhook subscription not found")
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+	ErrInvalidWebhookEventType = errors.New("invalid webhook event type")
+	ErrWebhookDeliveryNotDead  = errors.New("only dead webhook deliveries can be replayed")
+)
+
+var WebhookErrors = []error{
+	ErrWebhookNotFound,
+	ErrWebhookDeliveryNotFound,
+	ErrInvalidWebhookEventType,
+	ErrWebhookDeliveryNotDead,
+}
@@ -0,0 +1,29 @@
+package constants
+
+type EventType string
+type OutboxStatus string
+
+const (
+	EventFieldScheduleCreated       EventType = "field_schedule.created"
+	EventFieldScheduleStatusChanged EventType = "field_schedule.status_changed"
+	EventFieldUpdated               EventType = "field.updated"
+
+	EventOrderPaid      EventType = "order.paid"
+	EventOrderCancelled EventType = "order.cancelled"
+	EventOrderExpired   EventType = "order.expired"
+
+	OutboxPending   OutboxStatus = "pending"
+	OutboxPublished OutboxStatus = "published"
+	OutboxFailed    OutboxStatus = "failed"
+)
+
+const (
+	AggregateField         = "field"
+	AggregateFieldSchedule = "field_schedule"
+)
+
+const (
+	BrokerLog    = "log"
+	BrokerMemory = "memory"
+	BrokerNATS   = "nats"
+)
@@ -6,5 +6,14 @@ var (
 	XServiceName  = textproto.CanonicalMIMEHeaderKey("x-service-name")
 	XApiKey       = textproto.CanonicalMIMEHeaderKey("x-api-key")
 	XRequestAt    = textproto.CanonicalMIMEHeaderKey("x-request-at")
+	XRequestID    = textproto.CanonicalMIMEHeaderKey("x-request-id")
 	Authorization = textproto.CanonicalMIMEHeaderKey("Authorization")
+
+	IdempotencyKey      = textproto.CanonicalMIMEHeaderKey("Idempotency-Key")
+	IdempotencyReplayed = textproto.CanonicalMIMEHeaderKey("Idempotency-Replayed")
+
+	XWebhookID        = textproto.CanonicalMIMEHeaderKey("x-webhook-id")
+	XWebhookEvent     = textproto.CanonicalMIMEHeaderKey("x-webhook-event")
+	XWebhookTimestamp = textproto.CanonicalMIMEHeaderKey("x-webhook-timestamp")
+	XWebhookSignature = textproto.CanonicalMIMEHeaderKey("x-webhook-signature")
 )
@@ -0,0 +1,14 @@
+package constants
+
+const (
+	FieldRead        = "field:read"
+	FieldWrite       = "field:write"
+	ScheduleRead     = "schedule:read"
+	ScheduleWrite    = "schedule:write"
+	ScheduleGenerate = "schedule:generate"
+	TimeRead         = "time:read"
+	TimeWrite        = "time:write"
+	AuditRead        = "audit:read"
+	WebhookManage    = "webhook:manage"
+	TrashManage      = "trash:manage"
+)
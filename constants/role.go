@@ -1,6 +1,8 @@
 package constants
 
 const (
-	Admin    = "admin"
-	Customer = "customer"
+	Admin        = "admin"
+	Staff        = "staff"
+	VenueManager = "venue_manager"
+	Customer     = "customer"
 )
@@ -0,0 +1,34 @@
+package constants
+
+type WebhookEventType string
+type WebhookDeliveryStatus string
+
+const (
+	WebhookAllEvents           WebhookEventType = "*"
+	WebhookScheduleBooked      WebhookEventType = "schedule.booked"
+	WebhookScheduleReleased    WebhookEventType = "schedule.released"
+	WebhookScheduleRescheduled WebhookEventType = "schedule.rescheduled"
+	WebhookFieldPriceChanged   WebhookEventType = "field.price_changed"
+
+	WebhookPending   WebhookDeliveryStatus = "pending"
+	WebhookDelivered WebhookDeliveryStatus = "delivered"
+	WebhookDead      WebhookDeliveryStatus = "dead"
+)
+
+var WebhookEventTypes = []WebhookEventType{
+	WebhookAllEvents,
+	WebhookScheduleBooked,
+	WebhookScheduleReleased,
+	WebhookScheduleRescheduled,
+	WebhookFieldPriceChanged,
+}
+
+func (w WebhookEventType) IsValid() bool {
+	for _, eventType := range WebhookEventTypes {
+		if eventType == w {
+			return true
+		}
+	}
+
+	return false
+}
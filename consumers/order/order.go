@@ -0,0 +1,270 @@
+package consumers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"field-service/common/broker"
+	"field-service/common/logger"
+	"field-service/constants"
+	errMessage "field-service/constants/error/message"
+	"field-service/domain/dto"
+	"field-service/domain/models"
+	"field-service/repositories"
+	auditLogService "field-service/services/auditlog"
+	fieldScheduleService "field-service/services/fieldschedule"
+	webhookService "field-service/services/webhook"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	consumerName        = "order-consumer"
+	defaultMaxAttempts  = 5
+	defaultRetryBackoff = 500 * time.Millisecond
+	maxRetryBackoff     = 30 * time.Second
+)
+
+var (
+	errInvalidOrderEvent = errors.New("invalid order event")
+	errConsumerStopped   = errors.New("order consumer is stopping")
+)
+
+type OrderConsumerConfig struct {
+	MaxAttempts  int
+	RetryBackoff time.Duration
+}
+
+type OrderConsumer struct {
+	repositories repositories.IRepostitoryRegistry
+	subscriber   broker.ISubscriber
+	config       OrderConsumerConfig
+	mutex        sync.Mutex
+	stopped      bool
+	inFlight     sync.WaitGroup
+}
+
+type IOrderConsumer interface {
+	Start(context.Context) error
+	Wait()
+	Replay(context.Context, int) (int, error)
+}
+
+func NewOrderConsumer(
+	repositories repositories.IRepostitoryRegistry,
+	subscriber broker.ISubscriber,
+	config OrderConsumerConfig,
+) IOrderConsumer {
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = defaultMaxAttempts
+	}
+
+	if config.RetryBackoff <= 0 {
+		config.RetryBackoff = defaultRetryBackoff
+	}
+
+	return &OrderConsumer{
+		repositories: repositories,
+		subscriber:   subscriber,
+		config:       config,
+	}
+}
+
+func (o *OrderConsumer) statusFor(topic string) (constants.FieldScheduleStatus, bool) {
+	switch constants.EventType(topic) {
+	case constants.EventOrderPaid:
+		return constants.Booked, true
+	case constants.EventOrderCancelled, constants.EventOrderExpired:
+		return constants.Available, true
+	default:
+		return 0, false
+	}
+}
+
+// Start subscribes to the order topics. Messages are handled on the broker's goroutines.
+func (o *OrderConsumer) Start(ctx context.Context) error {
+	topics := []constants.EventType{
+		constants.EventOrderPaid,
+		constants.EventOrderCancelled,
+		constants.EventOrderExpired,
+	}
+
+	for _, topic := range topics {
+		err := o.subscriber.Subscribe(ctx, string(topic), o.handle)
+		if err != nil {
+			logger.FromContext(ctx).Errorf("failed to subscribe to %s: %v", topic, err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Wait stops taking new messages and blocks until the ones being handled are
+// done, so shutdown can close the broker and database after it returns.
+func (o *OrderConsumer) Wait() {
+	o.mutex.Lock()
+	o.stopped = true
+	o.mutex.Unlock()
+
+	o.inFlight.Wait()
+}
+
+// track registers a message as in flight unless Wait has already been called.
+func (o *OrderConsumer) track() bool {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if o.stopped {
+		return false
+	}
+
+	o.inFlight.Add(1)
+	return true
+}
+
+// messageID falls back to a hash of the message when the publisher did not set an ID,
+// so redeliveries of the same payload are still recognised.
+func (o *OrderConsumer) messageID(message broker.Message) string {
+	if message.ID != "" {
+		return message.ID
+	}
+
+	hash := sha256.Sum256(append([]byte(message.Topic+":"), message.Payload...))
+	return hex.EncodeToString(hash[:])
+}
+
+// handle retries a message with backoff and moves it to the dead letters once
+// it keeps failing, so it can be replayed later instead of being lost.
+func (o *OrderConsumer) handle(ctx context.Context, message broker.Message) error {
+	if !o.track() {
+		return errConsumerStopped
+	}
+	defer o.inFlight.Done()
+
+	messageID := o.messageID(message)
+	ctx = context.WithValue(ctx, constants.ServiceName, consumerName)
+	ctx = context.WithValue(ctx, constants.RequestID, messageID)
+
+	var (
+		err     error
+		attempt int
+		backoff = o.config.RetryBackoff
+	)
+	for attempt = 1; ; attempt++ {
+		err = o.process(ctx, messageID, message)
+		if err == nil || errors.Is(err, errInvalidOrderEvent) || attempt >= o.config.MaxAttempts {
+			break
+		}
+
+		logger.FromContext(ctx).Warnf("order event %s failed (attempt %d/%d): %v", messageID, attempt, o.config.MaxAttempts, err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > maxRetryBackoff {
+			backoff = maxRetryBackoff
+		}
+	}
+
+	if err == nil {
+		return nil
+	}
+
+	logger.FromContext(ctx).Errorf("give up on order event %s (%s): %v", messageID, message.Topic, err)
+	return o.repositories.GetDeadLetterMessageRepository().Save(context.WithoutCancel(ctx), &models.DeadLetterMessage{
+		MessageID: messageID,
+		Topic:     message.Topic,
+		Payload:   message.Payload,
+		Attempts:  attempt,
+		LastError: err.Error(),
+	})
+}
+
+// Replay processes up to limit dead letters once more. Messages that go through
+// are removed; the others stay with their attempts and last error updated.
+func (o *OrderConsumer) Replay(ctx context.Context, limit int) (int, error) {
+	deadLetters, err := o.repositories.GetDeadLetterMessageRepository().FindAll(ctx, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	replayed := 0
+	for _, deadLetter := range deadLetters {
+		messageCtx := context.WithValue(ctx, constants.ServiceName, consumerName)
+		messageCtx = context.WithValue(messageCtx, constants.RequestID, deadLetter.MessageID)
+
+		err = o.process(messageCtx, deadLetter.MessageID, broker.Message{
+			ID:      deadLetter.MessageID,
+			Topic:   deadLetter.Topic,
+			Payload: deadLetter.Payload,
+		})
+		if err != nil {
+			logger.FromContext(messageCtx).Errorf("replay of order event %s failed: %v", deadLetter.MessageID, err)
+			err = o.repositories.GetDeadLetterMessageRepository().Save(messageCtx, &models.DeadLetterMessage{
+				MessageID: deadLetter.MessageID,
+				Topic:     deadLetter.Topic,
+				Payload:   deadLetter.Payload,
+				Attempts:  1,
+				LastError: err.Error(),
+			})
+			if err != nil {
+				return replayed, err
+			}
+			continue
+		}
+
+		err = o.repositories.GetDeadLetterMessageRepository().Delete(messageCtx, deadLetter.ID)
+		if err != nil {
+			return replayed, err
+		}
+		replayed++
+	}
+
+	return replayed, nil
+}
+
+// process claims the message and applies it in one transaction. The claim
+// relies on the unique message ID, so a redelivery racing this one either waits
+// and finds it processed or rolls back with the status change it made.
+func (o *OrderConsumer) process(ctx context.Context, messageID string, message broker.Message) error {
+	status, ok := o.statusFor(message.Topic)
+	if !ok {
+		return errInvalidOrderEvent
+	}
+
+	var event dto.OrderEvent
+	err := json.Unmarshal(message.Payload, &event)
+	if err != nil || event.OrderUUID == uuid.Nil || len(event.FieldScheduleIDs) == 0 {
+		return errInvalidOrderEvent
+	}
+
+	err = o.repositories.Transaction(ctx, func(tx repositories.IRepostitoryRegistry) error {
+		now := time.Now()
+		err := tx.GetProcessedMessageRepository().Create(ctx, &models.ProcessedMessage{
+			MessageID:   messageID,
+			Topic:       message.Topic,
+			ProcessedAt: &now,
+		})
+		if err != nil {
+			return err
+		}
+
+		fieldSchedule := fieldScheduleService.NewFieldScheduleService(
+			tx, auditLogService.NewAuditLogService(tx), webhookService.NewWebhookService(tx))
+		return fieldSchedule.SetStatus(ctx, status, &event.OrderUUID, event.FieldScheduleIDs)
+	})
+	if errors.Is(err, errMessage.ErrMessageProcessed) {
+		logger.FromContext(ctx).Infof("skip order event %s: already processed", messageID)
+		return nil
+	}
+
+	return err
+}
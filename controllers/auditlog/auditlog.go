@@ -0,0 +1,70 @@
+package controllers
+
+import (
+	errValidation "field-service/common/error"
+	"field-service/common/response"
+	"field-service/domain/dto"
+	"field-service/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type AuditLogController struct {
+	service services.IServiceRegistry
+}
+
+type IAuditLogController interface {
+	GetAllWithPagination(*gin.Context)
+}
+
+func NewAuditLogController(service services.IServiceRegistry) IAuditLogController {
+	return &AuditLogController{
+		service: service,
+	}
+}
+
+func (a *AuditLogController) GetAllWithPagination(c *gin.Context) {
+	var params dto.AuditLogRequestParam
+	err := c.ShouldBindQuery(&params)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:  http.StatusBadRequest,
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(params)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:    http.StatusBadRequest,
+			Message: &errMessage,
+			Data:    errResponse,
+			Error:   err,
+			Gin:     c,
+		})
+		return
+	}
+
+	result, err := a.service.GetAuditLog().GetAllWithPagination(c, &params)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:  http.StatusInternalServerError,
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
@@ -1,8 +1,13 @@
 package controllers
 
 import (
+	"errors"
 	errValidation "field-service/common/error"
+	"field-service/common/logger"
 	"field-service/common/response"
+	errConstants "field-service/constants/error"
+	errField "field-service/constants/error/field"
+	errFieldSchedule "field-service/constants/error/field_schedule"
 	"field-service/domain/dto"
 	"field-service/services"
 	"net/http"
@@ -10,7 +15,6 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
-	"github.com/sirupsen/logrus"
 )
 
 type FieldController struct {
@@ -20,10 +24,13 @@ type FieldController struct {
 type IFieldController interface {
 	GetAllWithPagination(*gin.Context)
 	GetAllWithoutPagination(*gin.Context)
+	Search(*gin.Context)
 	GetByUUID(*gin.Context)
 	Create(*gin.Context)
 	Update(*gin.Context)
 	Delete(*gin.Context)
+	GetTrash(*gin.Context)
+	Restore(*gin.Context)
 }
 
 func NewFieldController(service services.IServiceRegistry) IFieldController {
@@ -59,10 +66,15 @@ func (f *FieldController) GetAllWithPagination(c *gin.Context) {
 		return
 	}
 
-	result, err := f.service.GetField().GetAllWithPagination(c, &params)
+	var result any
+	if params.Page > 0 {
+		result, err = f.service.GetField().GetAllWithPagination(c, &params)
+	} else {
+		result, err = f.service.GetField().GetAllWithCursor(c, &params)
+	}
 	if err != nil {
 		response.HttpResponse(response.ParamHTTPResp{
-			Code:  http.StatusInternalServerError,
+			Code:  listErrorStatus(err),
 			Error: err,
 			Gin:   c,
 		})
@@ -94,6 +106,50 @@ func (f *FieldController) GetAllWithoutPagination(c *gin.Context) {
 	})
 }
 
+func (f *FieldController) Search(c *gin.Context) {
+	var params dto.FieldSearchRequestParam
+	err := c.ShouldBindQuery(&params)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:  http.StatusBadRequest,
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(params)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:    http.StatusBadRequest,
+			Error:   err,
+			Message: &errMessage,
+			Data:    errResponse,
+			Gin:     c,
+		})
+		return
+	}
+
+	result, err := f.service.GetField().Search(c, &params)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:  http.StatusInternalServerError,
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
 func (f *FieldController) GetByUUID(c *gin.Context) {
 	result, err := f.service.GetField().GetByUUID(c, c.Param("uuid"))
 	if err != nil {
@@ -116,7 +172,7 @@ func (f *FieldController) Create(c *gin.Context) {
 	var request dto.FieldRequest
 	err := c.ShouldBindWith(&request, binding.FormMultipart)
 	if err != nil {
-		logrus.Error("Controller Create - 1", err)
+		logger.FromContext(c).Error("Controller Create - 1", err)
 		response.HttpResponse(response.ParamHTTPResp{
 			Code:  http.StatusBadRequest,
 			Error: err,
@@ -128,7 +184,7 @@ func (f *FieldController) Create(c *gin.Context) {
 	validate := validator.New()
 	err = validate.Struct(request)
 	if err != nil {
-		logrus.Error("Controller Create - 2:", err)
+		logger.FromContext(c).Error("Controller Create - 2:", err)
 		errMessage := http.StatusText(http.StatusUnprocessableEntity)
 		errResponse := errValidation.ErrValidationResponse(err)
 		response.HttpResponse(response.ParamHTTPResp{
@@ -144,7 +200,7 @@ func (f *FieldController) Create(c *gin.Context) {
 
 	result, err := f.service.GetField().Create(c, &request)
 	if err != nil {
-		logrus.Error("Controller Create - 3:", err)
+		logger.FromContext(c).Error("Controller Create - 3:", err)
 		response.HttpResponse(response.ParamHTTPResp{
 			Code:  http.StatusInternalServerError,
 			Error: err,
@@ -220,3 +276,90 @@ func (f *FieldController) Delete(c *gin.Context) {
 		Gin:  c,
 	})
 }
+
+func (f *FieldController) GetTrash(c *gin.Context) {
+	var params dto.TrashRequestParam
+	err := c.ShouldBindQuery(&params)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:  http.StatusBadRequest,
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(params)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:    http.StatusBadRequest,
+			Error:   err,
+			Message: &errMessage,
+			Data:    errResponse,
+			Gin:     c,
+		})
+		return
+	}
+
+	result, err := f.service.GetField().GetTrash(c, &params)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:  http.StatusInternalServerError,
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (f *FieldController) Restore(c *gin.Context) {
+	result, err := f.service.GetField().Restore(c, c.Param("uuid"))
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:  restoreErrorStatus(err),
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+// listErrorStatus reports an unknown sort, an impossible filter range or a
+// cursor the client altered as a bad request.
+func listErrorStatus(err error) int {
+	if errors.Is(err, errConstants.ErrInvalidCursor) ||
+		errors.Is(err, errConstants.ErrInvalidSort) ||
+		errors.Is(err, errConstants.ErrInvalidFilter) {
+		return http.StatusBadRequest
+	}
+
+	return http.StatusInternalServerError
+}
+
+// restoreErrorStatus reports a field that is not in the trash as not found. A
+// schedule of it whose slot was taken in the meantime is a conflict.
+func restoreErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, errField.ErrFieldNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, errFieldSchedule.ErrFieldShceduleExist):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -1,8 +1,13 @@
 package controllers
 
 import (
+	"errors"
 	errValidation "field-service/common/error"
 	"field-service/common/response"
+	errConstants "field-service/constants/error"
+	errField "field-service/constants/error/field"
+	errFieldSchedule "field-service/constants/error/field_schedule"
+	errTime "field-service/constants/error/time"
 	"field-service/domain/dto"
 	"field-service/services"
 	"net/http"
@@ -24,6 +29,8 @@ type IFieldScheduleController interface {
 	Update(*gin.Context)
 	UpdateStatus(*gin.Context)
 	Delete(*gin.Context)
+	GetTrash(*gin.Context)
+	Restore(*gin.Context)
 }
 
 func NewFieldScheduleController(service services.IServiceRegistry) IFieldScheduleController {
@@ -32,6 +39,28 @@ func NewFieldScheduleController(service services.IServiceRegistry) IFieldSchedul
 	}
 }
 
+// writeErrorStatus reports a slot that is already taken as a conflict; any other
+// service error stays an internal server error.
+func writeErrorStatus(err error) int {
+	if errors.Is(err, errFieldSchedule.ErrFieldShceduleExist) {
+		return http.StatusConflict
+	}
+
+	return http.StatusInternalServerError
+}
+
+// listErrorStatus reports an unknown sort, an impossible filter range or a
+// cursor the client altered as a bad request.
+func listErrorStatus(err error) int {
+	if errors.Is(err, errConstants.ErrInvalidCursor) ||
+		errors.Is(err, errConstants.ErrInvalidSort) ||
+		errors.Is(err, errConstants.ErrInvalidFilter) {
+		return http.StatusBadRequest
+	}
+
+	return http.StatusInternalServerError
+}
+
 func (f *FieldScheduleController) GetAllWithPagination(c *gin.Context) {
 	var params dto.FieldScheduleRequestParam
 	err := c.ShouldBindQuery(&params)
@@ -59,10 +88,15 @@ func (f *FieldScheduleController) GetAllWithPagination(c *gin.Context) {
 		return
 	}
 
-	result, err := f.service.GetFieldSchedule().GetAllWithPagination(c, &params)
+	var result any
+	if params.Page > 0 {
+		result, err = f.service.GetFieldSchedule().GetAllWithPagination(c, &params)
+	} else {
+		result, err = f.service.GetFieldSchedule().GetAllWithCursor(c, &params)
+	}
 	if err != nil {
 		response.HttpResponse(response.ParamHTTPResp{
-			Code:  http.StatusInternalServerError,
+			Code:  listErrorStatus(err),
 			Error: err,
 			Gin:   c,
 		})
@@ -168,7 +202,7 @@ func (f *FieldScheduleController) GenerateScheduleForOneMonth(c *gin.Context) {
 	err = f.service.GetFieldSchedule().GenerateScheduleForOneMonth(c, &request)
 	if err != nil {
 		response.HttpResponse(response.ParamHTTPResp{
-			Code:  http.StatusInternalServerError,
+			Code:  writeErrorStatus(err),
 			Error: err,
 			Gin:   c,
 		})
@@ -212,7 +246,7 @@ func (f *FieldScheduleController) Create(c *gin.Context) {
 	err = f.service.GetFieldSchedule().Create(c, &request)
 	if err != nil {
 		response.HttpResponse(response.ParamHTTPResp{
-			Code:  http.StatusInternalServerError,
+			Code:  writeErrorStatus(err),
 			Error: err,
 			Gin:   c,
 		})
@@ -255,7 +289,7 @@ func (f *FieldScheduleController) Update(c *gin.Context) {
 	result, err := f.service.GetFieldSchedule().Update(c, c.Param("uuid"), &request)
 	if err != nil {
 		response.HttpResponse(response.ParamHTTPResp{
-			Code:  http.StatusInternalServerError,
+			Code:  writeErrorStatus(err),
 			Error: err,
 			Gin:   c,
 		})
@@ -330,3 +364,80 @@ func (f *FieldScheduleController) Delete(c *gin.Context) {
 	})
 
 }
+
+func (f *FieldScheduleController) GetTrash(c *gin.Context) {
+	var params dto.TrashRequestParam
+	err := c.ShouldBindQuery(&params)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:  http.StatusBadRequest,
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(params)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:    http.StatusBadRequest,
+			Error:   err,
+			Message: &errMessage,
+			Data:    errResponse,
+			Gin:     c,
+		})
+		return
+	}
+
+	result, err := f.service.GetFieldSchedule().GetTrash(c, &params)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:  http.StatusInternalServerError,
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (f *FieldScheduleController) Restore(c *gin.Context) {
+	result, err := f.service.GetFieldSchedule().Restore(c, c.Param("uuid"))
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:  restoreErrorStatus(err),
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+// restoreErrorStatus reports a schedule that is not in the trash as not found.
+// A taken slot, or a field or time that is itself trashed, is a conflict.
+func restoreErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, errFieldSchedule.ErrFieldScheduleNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, errFieldSchedule.ErrFieldShceduleExist),
+		errors.Is(err, errField.ErrFieldNotFound),
+		errors.Is(err, errTime.ErrTimeNotFound):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
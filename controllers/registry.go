@@ -1,9 +1,11 @@
 package controllers
 
 import (
+	auditLogController "field-service/controllers/auditlog"
 	fieldController "field-service/controllers/field"
 	fieldScheduleController "field-service/controllers/fieldschedule"
 	timeController "field-service/controllers/time"
+	webhookController "field-service/controllers/webhook"
 	"field-service/services"
 )
 
@@ -15,6 +17,8 @@ type IControllerRegistry interface {
 	GetField() fieldController.IFieldController
 	GetFieldSchedule() fieldScheduleController.IFieldScheduleController
 	GetTime() timeController.ITimeController
+	GetAuditLog() auditLogController.IAuditLogController
+	GetWebhook() webhookController.IWebhookController
 }
 
 func NewControllerRegistry(services services.IServiceRegistry) IControllerRegistry {
@@ -32,3 +36,11 @@ func (c *ControllerRegistry) GetFieldSchedule() fieldScheduleController.IFieldSc
 func (c *ControllerRegistry) GetTime() timeController.ITimeController {
 	return timeController.NewTimeController(c.services)
 }
+
+func (c *ControllerRegistry) GetAuditLog() auditLogController.IAuditLogController {
+	return auditLogController.NewAuditLogController(c.services)
+}
+
+func (c *ControllerRegistry) GetWebhook() webhookController.IWebhookController {
+	return webhookController.NewWebhookController(c.services)
+}
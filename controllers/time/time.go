@@ -1,8 +1,11 @@
 package controllers
 
 import (
+	"errors"
 	errValidation "field-service/common/error"
 	"field-service/common/response"
+	errFieldSchedule "field-service/constants/error/field_schedule"
+	errTime "field-service/constants/error/time"
 	"field-service/domain/dto"
 	"field-service/services"
 	"net/http"
@@ -19,6 +22,9 @@ type ITimeController interface {
 	GetAll(*gin.Context)
 	GetByUUID(*gin.Context)
 	Create(*gin.Context)
+	Delete(*gin.Context)
+	GetTrash(*gin.Context)
+	Restore(*gin.Context)
 }
 
 func NewTimeController(service services.IServiceRegistry) ITimeController {
@@ -107,3 +113,96 @@ func (t *TimeController) Create(c *gin.Context) {
 		Gin:  c,
 	})
 }
+
+func (t *TimeController) Delete(c *gin.Context) {
+	err := t.service.GetTime().Delete(c, c.Param("uuid"))
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:  http.StatusInternalServerError,
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Gin:  c,
+	})
+}
+
+func (t *TimeController) GetTrash(c *gin.Context) {
+	var params dto.TrashRequestParam
+	err := c.ShouldBindQuery(&params)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:  http.StatusBadRequest,
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(params)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:    http.StatusBadRequest,
+			Error:   err,
+			Message: &errMessage,
+			Data:    errResponse,
+			Gin:     c,
+		})
+		return
+	}
+
+	result, err := t.service.GetTime().GetTrash(c, &params)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:  http.StatusInternalServerError,
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (t *TimeController) Restore(c *gin.Context) {
+	result, err := t.service.GetTime().Restore(c, c.Param("uuid"))
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:  restoreErrorStatus(err),
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+// restoreErrorStatus reports a slot that is not in the trash as not found. An
+// identical live slot, or a schedule slot taken in the meantime, is a conflict.
+func restoreErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, errTime.ErrTimeNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, errTime.ErrTimeExist),
+		errors.Is(err, errFieldSchedule.ErrFieldShceduleExist):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
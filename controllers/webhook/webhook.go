@@ -0,0 +1,185 @@
+package controllers
+
+import (
+	"errors"
+	errValidation "field-service/common/error"
+	"field-service/common/response"
+	errWebhook "field-service/constants/error/webhook"
+	"field-service/domain/dto"
+	"field-service/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type WebhookController struct {
+	service services.IServiceRegistry
+}
+
+type IWebhookController interface {
+	GetAllSubscriptions(*gin.Context)
+	CreateSubscription(*gin.Context)
+	DeleteSubscription(*gin.Context)
+	GetDeadLetters(*gin.Context)
+	Replay(*gin.Context)
+}
+
+func NewWebhookController(service services.IServiceRegistry) IWebhookController {
+	return &WebhookController{
+		service: service,
+	}
+}
+
+func (w *WebhookController) GetAllSubscriptions(c *gin.Context) {
+	result, err := w.service.GetWebhook().GetAllSubscriptions(c)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:  http.StatusInternalServerError,
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (w *WebhookController) CreateSubscription(c *gin.Context) {
+	var request dto.WebhookSubscriptionRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:  http.StatusBadRequest,
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:    http.StatusBadRequest,
+			Message: &errMessage,
+			Data:    errResponse,
+			Error:   err,
+			Gin:     c,
+		})
+		return
+	}
+
+	result, err := w.service.GetWebhook().CreateSubscription(c, &request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:  http.StatusInternalServerError,
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusCreated,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (w *WebhookController) DeleteSubscription(c *gin.Context) {
+	err := w.service.GetWebhook().DeleteSubscription(c, c.Param("uuid"))
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:  http.StatusInternalServerError,
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Gin:  c,
+	})
+}
+
+func (w *WebhookController) GetDeadLetters(c *gin.Context) {
+	var params dto.WebhookDeliveryRequestParam
+	err := c.ShouldBindQuery(&params)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:  http.StatusBadRequest,
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(params)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:    http.StatusBadRequest,
+			Message: &errMessage,
+			Data:    errResponse,
+			Error:   err,
+			Gin:     c,
+		})
+		return
+	}
+
+	result, err := w.service.GetWebhook().GetDeadLetters(c, &params)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:  http.StatusInternalServerError,
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (w *WebhookController) Replay(c *gin.Context) {
+	err := w.service.GetWebhook().Replay(c, c.Param("uuid"))
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:  replayErrorStatus(err),
+			Error: err,
+			Gin:   c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Gin:  c,
+	})
+}
+
+// replayErrorStatus reports an unknown delivery as not found and one that is
+// not dead as a conflict.
+func replayErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, errWebhook.ErrWebhookDeliveryNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, errWebhook.ErrWebhookDeliveryNotDead):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,49 @@
+package dto
+
+import (
+	"encoding/json"
+	"field-service/constants"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Audit log record written by the services
+type AuditLogRecord struct {
+	Action     constants.AuditAction
+	Entity     constants.AuditEntity
+	EntityUUID uuid.UUID
+	Before     any
+	After      any
+}
+
+// Audit log response
+type AuditLogResponse struct {
+	UUID        uuid.UUID       `json:"uuid"`
+	ActorUUID   *uuid.UUID      `json:"actorUUID"`
+	ActorName   string          `json:"actorName"`
+	ActorRole   string          `json:"actorRole"`
+	ServiceName string          `json:"serviceName"`
+	Action      string          `json:"action"`
+	Entity      string          `json:"entity"`
+	EntityUUID  uuid.UUID       `json:"entityUUID"`
+	Before      json.RawMessage `json:"before"`
+	After       json.RawMessage `json:"after"`
+	Diff        json.RawMessage `json:"diff"`
+	RequestID   string          `json:"requestID"`
+	SourceIP    string          `json:"sourceIP"`
+	CreatedAt   *time.Time      `json:"createdAt"`
+}
+
+// Audit log request params
+type AuditLogRequestParam struct {
+	Page       int     `form:"page" validate:"required,min=1"`
+	Limit      int     `form:"limit" validate:"required,min=1,max=100"`
+	ActorUUID  *string `form:"actorUUID" validate:"omitempty,uuid"`
+	Action     *string `form:"action"`
+	Entity     *string `form:"entity"`
+	EntityUUID *string `form:"entityUUID" validate:"omitempty,uuid"`
+	RequestID  *string `form:"requestID"`
+	StartDate  *string `form:"startDate" validate:"omitempty,datetime=2006-01-02"`
+	EndDate    *string `form:"endDate" validate:"omitempty,datetime=2006-01-02"`
+}
@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"field-service/constants"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Domain event envelope written to the outbox
+type DomainEvent struct {
+	ID            uuid.UUID           `json:"id"`
+	Type          constants.EventType `json:"type"`
+	AggregateType string              `json:"aggregateType"`
+	AggregateUUID uuid.UUID           `json:"aggregateUUID"`
+	OccurredAt    time.Time           `json:"occurredAt"`
+	Data          any                 `json:"data"`
+}
+
+// Field schedule domain event data
+type FieldScheduleEvent struct {
+	UUID    uuid.UUID                         `json:"uuid"`
+	FieldID uint                              `json:"fieldID"`
+	TimeID  uint                              `json:"timeID"`
+	Date    string                            `json:"date"`
+	Status  constants.FieldScheduleStatusName `json:"status"`
+}
+
+// Field domain event data
+type FieldEvent struct {
+	UUID         uuid.UUID `json:"uuid"`
+	Code         string    `json:"code"`
+	Name         string    `json:"name"`
+	PricePerHour int       `json:"pricePerHour"`
+	Images       []string  `json:"images"`
+}
+
+// Order event data consumed from the order service
+type OrderEvent struct {
+	OrderUUID        uuid.UUID `json:"orderUUID"`
+	FieldScheduleIDs []string  `json:"fieldScheduleIDs"`
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 type FieldRequest struct {
@@ -29,6 +30,18 @@ type FieldResponse struct {
 	Images       []string   `json:"images"`
 	CreatedAt    *time.Time `json:"createdAt"`
 	UpdatedAt    *time.Time `json:"updatedAt"`
+	DeletedAt    *time.Time `json:"deletedAt,omitempty"`
+}
+
+// Field response with raw values for internal consumers
+type InternalFieldResponse struct {
+	UUID         uuid.UUID
+	Name         string
+	Code         string
+	PricePerHour int
+	Images       []string
+	CreatedAt    *time.Time
+	UpdatedAt    *time.Time
 }
 
 type FieldDetailResponse struct {
@@ -41,8 +54,44 @@ type FieldDetailResponse struct {
 }
 
 type FieldRequestParam struct {
-	Page       int     `form:"page" validate:"required"`
-	Limit      int     `form:"limit" validate:"required"`
+	Page  int `form:"page" validate:"excluded_with=Cursor"`
+	Limit int `form:"limit" validate:"required"`
+	// SortColumn lists up to three of name, code, price_per_hour, created_at and
+	// updated_at, comma separated. SortOrder is one asc or desc for all of them
+	// or one per column.
 	SortColumn *string `form:"sortColumn"`
 	SortOrder  *string `form:"sortOrder"`
+	// Without a page the listing is keyset paginated: Cursor continues from the
+	// previous nextCursor and WithCount adds the total.
+	Cursor    *string `form:"cursor"`
+	WithCount bool    `form:"withCount"`
+	Name      *string `form:"name" validate:"omitempty,max=200"`
+	MinPrice  *int    `form:"minPrice" validate:"omitempty,min=0"`
+	MaxPrice  *int    `form:"maxPrice" validate:"omitempty,min=0"`
+}
+
+type FieldSearchRequestParam struct {
+	Query string `form:"q" validate:"required,max=200"`
+	Page  int    `form:"page" validate:"required,min=1"`
+	Limit int    `form:"limit" validate:"required,min=1,max=100"`
+}
+
+// Field matched by a search, as read from the database
+type FieldSearchResult struct {
+	UUID         uuid.UUID
+	Code         string
+	Name         string
+	PricePerHour int
+	Images       pq.StringArray `gorm:"type:text[]"`
+	CreatedAt    *time.Time
+	UpdatedAt    *time.Time
+	Rank         float64
+	Snippet      string
+}
+
+// Snippet is HTML-escaped and marks the matched words with <mark> tags
+type FieldSearchResponse struct {
+	FieldResponse
+	Rank    float64 `json:"rank"`
+	Snippet string  `json:"snippet"`
 }
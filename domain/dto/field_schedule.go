@@ -27,7 +27,8 @@ type UpdateFieldScheduleRequest struct {
 
 // Update status field schedule request
 type UpdateStatusFieldScheduleRequest struct {
-	FieldScheduleIDs []string `json:"fieldScheduleIDs" validate:"required"`
+	FieldScheduleIDs []string   `json:"fieldScheduleIDs" validate:"required"`
+	OrderUUID        *uuid.UUID `json:"orderUUID"`
 }
 
 // Field schedule response
@@ -40,6 +41,7 @@ type FieldScheduleReponse struct {
 	Time         string                            `json:"time"`
 	CreatedAt    *time.Time                        `json:"createdAt"`
 	UpdatedAt    *time.Time                        `json:"updatedAt"`
+	DeletedAt    *time.Time                        `json:"deletedAt,omitempty"`
 }
 
 // field schedule for booking response
@@ -52,14 +54,52 @@ type FieldScheduleForBookingResponse struct {
 	Time         string                            `json:"time"`
 }
 
+// field schedule response with raw values for internal consumers
+type InternalFieldScheduleResponse struct {
+	UUID         uuid.UUID
+	FieldUUID    uuid.UUID
+	FieldName    string
+	Date         time.Time
+	PricePerHour int
+	Status       constants.FieldScheduleStatus
+	TimeUUID     uuid.UUID
+	StartTime    string
+	EndTime      string
+	CreatedAt    *time.Time
+	UpdatedAt    *time.Time
+}
+
 // field schedule request params
 type FieldScheduleRequestParam struct {
-	Page       int     `form:"page" validate:"required"`
-	Limit      int     `form:"limit" validate:"required"`
+	Page  int `form:"page" validate:"excluded_with=Cursor"`
+	Limit int `form:"limit" validate:"required"`
+	// SortColumn lists up to three of date, start_time, status, field_name,
+	// price_per_hour, created_at and updated_at, comma separated. SortOrder is
+	// one asc or desc for all of them or one per column.
 	SortColumn *string `form:"sortColumn"`
 	SortOrder  *string `form:"sortOrder"`
+	// Without a page the listing is keyset paginated: Cursor continues from the
+	// previous nextCursor and WithCount adds the total.
+	Cursor    *string                            `form:"cursor"`
+	WithCount bool                               `form:"withCount"`
+	FieldUUID *string                            `form:"fieldUUID" validate:"omitempty,uuid"`
+	StartDate *string                            `form:"startDate" validate:"omitempty,datetime=2006-01-02"`
+	EndDate   *string                            `form:"endDate" validate:"omitempty,datetime=2006-01-02"`
+	Status    *constants.FieldScheduleStatusName `form:"status" validate:"omitempty,oneof=Available Booked"`
+	StartTime *string                            `form:"startTime" validate:"omitempty,datetime=15:04"`
+	EndTime   *string                            `form:"endTime" validate:"omitempty,datetime=15:04"`
+	MinPrice  *int                               `form:"minPrice" validate:"omitempty,min=0"`
+	MaxPrice  *int                               `form:"maxPrice" validate:"omitempty,min=0"`
+	Name      *string                            `form:"name" validate:"omitempty,max=200"`
 }
 
 type FieldScheduleByFieldIDAndDateRequestParam struct {
 	Date string `form:"date" validate:"required"`
 }
+
+// available field schedule count per field
+type AvailableSlotCount struct {
+	FieldUUID uuid.UUID
+	FieldName string
+	Total     int64
+}
@@ -17,4 +17,5 @@ type TimeResponse struct {
 	EndTime   string     `json:"endTime"`
 	CreatedAt *time.Time `json:"createdAt"`
 	UpdatedAt *time.Time `json:"updatedAt"`
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
 }
@@ -0,0 +1,6 @@
+package dto
+
+type TrashRequestParam struct {
+	Page  int `form:"page" validate:"required"`
+	Limit int `form:"limit" validate:"required"`
+}
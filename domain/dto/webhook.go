@@ -0,0 +1,76 @@
+package dto
+
+import (
+	"encoding/json"
+	"field-service/constants"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Webhook subscription request
+type WebhookSubscriptionRequest struct {
+	URL        string   `json:"url" validate:"required,url"`
+	EventTypes []string `json:"eventTypes" validate:"required,min=1"`
+	Secret     string   `json:"secret" validate:"required,min=16"`
+}
+
+// Webhook subscription response
+type WebhookSubscriptionResponse struct {
+	UUID       uuid.UUID  `json:"uuid"`
+	URL        string     `json:"url"`
+	EventTypes []string   `json:"eventTypes"`
+	IsActive   bool       `json:"isActive"`
+	CreatedAt  *time.Time `json:"createdAt"`
+	UpdatedAt  *time.Time `json:"updatedAt"`
+}
+
+// Webhook delivery response
+type WebhookDeliveryResponse struct {
+	UUID             uuid.UUID                       `json:"uuid"`
+	SubscriptionUUID uuid.UUID                       `json:"subscriptionUUID"`
+	URL              string                          `json:"url"`
+	EventID          uuid.UUID                       `json:"eventID"`
+	EventType        constants.WebhookEventType      `json:"eventType"`
+	Payload          json.RawMessage                 `json:"payload"`
+	Status           constants.WebhookDeliveryStatus `json:"status"`
+	Attempts         int                             `json:"attempts"`
+	LastStatusCode   int                             `json:"lastStatusCode"`
+	LastError        string                          `json:"lastError"`
+	NextAttemptAt    time.Time                       `json:"nextAttemptAt"`
+	CreatedAt        *time.Time                      `json:"createdAt"`
+	UpdatedAt        *time.Time                      `json:"updatedAt"`
+}
+
+// Webhook delivery request params
+type WebhookDeliveryRequestParam struct {
+	Page  int `form:"page" validate:"required"`
+	Limit int `form:"limit" validate:"required"`
+}
+
+// Webhook event envelope sent to subscribers
+type WebhookEvent struct {
+	ID         uuid.UUID                  `json:"id"`
+	Type       constants.WebhookEventType `json:"type"`
+	OccurredAt time.Time                  `json:"occurredAt"`
+	Data       any                        `json:"data"`
+}
+
+// Field schedule event data
+type FieldScheduleEventData struct {
+	UUID      uuid.UUID                         `json:"uuid"`
+	FieldUUID uuid.UUID                         `json:"fieldUUID"`
+	Date      string                            `json:"date"`
+	StartTime string                            `json:"startTime"`
+	EndTime   string                            `json:"endTime"`
+	Status    constants.FieldScheduleStatusName `json:"status"`
+}
+
+// Field price changed event data
+type FieldPriceChangedEventData struct {
+	UUID                 uuid.UUID `json:"uuid"`
+	Code                 string    `json:"code"`
+	Name                 string    `json:"name"`
+	PreviousPricePerHour int       `json:"previousPricePerHour"`
+	PricePerHour         int       `json:"pricePerHour"`
+}
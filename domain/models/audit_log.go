@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditLog struct {
+	ID          uint       `gorm:"primaryKey;autoIncrement"`
+	UUID        uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex"`
+	ActorUUID   *uuid.UUID `gorm:"type:uuid;index"`
+	ActorName   string     `gorm:"type:varchar(200)"`
+	ActorRole   string     `gorm:"type:varchar(50)"`
+	ServiceName string     `gorm:"type:varchar(100)"`
+	Action      string     `gorm:"type:varchar(50);not null;index"`
+	Entity      string     `gorm:"type:varchar(50);not null;index:idx_audit_logs_entity"`
+	EntityUUID  uuid.UUID  `gorm:"type:uuid;not null;index:idx_audit_logs_entity"`
+	Before      JSON       `gorm:"type:jsonb"`
+	After       JSON       `gorm:"type:jsonb"`
+	Diff        JSON       `gorm:"type:jsonb"`
+	RequestID   string     `gorm:"type:varchar(100);index"`
+	SourceIP    string     `gorm:"type:varchar(50)"`
+	CreatedAt   *time.Time `gorm:"index"`
+}
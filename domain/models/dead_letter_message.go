@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// DeadLetterMessage keeps a consumed message that kept failing, so it can be
+// inspected and replayed once the cause is fixed.
+type DeadLetterMessage struct {
+	ID        uint   `gorm:"primaryKey;autoIncrement"`
+	MessageID string `gorm:"type:varchar(255);not null;uniqueIndex"`
+	Topic     string `gorm:"type:varchar(100);not null"`
+	Payload   []byte `gorm:"type:bytea;not null"`
+	Attempts  int    `gorm:"type:int;not null;default:0"`
+	LastError string `gorm:"type:text"`
+	CreatedAt *time.Time
+	UpdatedAt *time.Time
+}
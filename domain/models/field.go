@@ -10,13 +10,13 @@ import (
 
 type Field struct {
 	ID             uint           `gorm:"primaryKey;autoIncrement"`
-	UUID           uuid.UUID      `gorm:"type:uuid;not null"`
+	UUID           uuid.UUID      `gorm:"type:uuid;not null;uniqueIndex"`
 	Code           string         `gorm:"type:varchar(15);not null"`
 	Name           string         `gorm:"type:varchar(200);not null"`
 	PricePerHour   int            `gorm:"type:int;not null"`
 	Images         pq.StringArray `gorm:"type:text[];not null"`
 	CreatedAt      *time.Time
 	UpdatedAt      *time.Time
-	DeletedAt      *gorm.DeletedAt
+	DeletedAt      *gorm.DeletedAt `gorm:"index"`
 	FieldSchedules []FieldSchedule `gorm:"foreignKey:field_id;references:id;constraint:OnDelete:CASCADE;OnUpdate:CASCADE"`
 }
@@ -5,18 +5,20 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type FieldSchedule struct {
 	ID        uint                          `gorm:"primaryKey;autoIncrement"`
-	UUID      uuid.UUID                     `gorm:"type:uuid;not null"`
-	FieldID   uint                          `gorm:"type:int;not null"`
-	TimeID    uint                          `gorm:"type:int;not null"`
-	Date      time.Time                     `gorm:"type:date;not null"`
+	UUID      uuid.UUID                     `gorm:"type:uuid;not null;uniqueIndex"`
+	FieldID   uint                          `gorm:"type:int;not null;uniqueIndex:idx_field_schedules_slot,priority:1,where:deleted_at IS NULL"`
+	TimeID    uint                          `gorm:"type:int;not null;uniqueIndex:idx_field_schedules_slot,priority:3"`
+	Date      time.Time                     `gorm:"type:date;not null;uniqueIndex:idx_field_schedules_slot,priority:2"`
 	Status    constants.FieldScheduleStatus `gorm:"type:int;not null"`
+	OrderUUID *uuid.UUID                    `gorm:"type:uuid"`
 	CreatedAt *time.Time
 	UpdatedAt *time.Time
-	DeletedAt *time.Time
-	Field     Field `gorm:"foreignKey:field_id;references:id;constraint:OnDelete:CASCADE;OnUpdate:CASCADE"`
-	Time      Time  `gorm:"foreignKey:time_id;references:id;constraint:OnDelete:CASCADE;OnUpdate:CASCADE"`
+	DeletedAt *gorm.DeletedAt `gorm:"index"`
+	Field     Field           `gorm:"foreignKey:field_id;references:id;constraint:OnDelete:CASCADE;OnUpdate:CASCADE"`
+	Time      Time            `gorm:"foreignKey:time_id;references:id;constraint:OnDelete:CASCADE;OnUpdate:CASCADE"`
 }
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"field-service/constants"
+	"time"
+)
+
+type IdempotencyKey struct {
+	ID           uint                        `gorm:"primaryKey;autoIncrement"`
+	Key          string                      `gorm:"type:varchar(255);not null;uniqueIndex:idx_idempotency_keys_key_caller"`
+	Caller       string                      `gorm:"type:varchar(255);not null;uniqueIndex:idx_idempotency_keys_key_caller"`
+	Method       string                      `gorm:"type:varchar(10);not null"`
+	Path         string                      `gorm:"type:varchar(500);not null"`
+	RequestHash  string                      `gorm:"type:varchar(64);not null"`
+	Status       constants.IdempotencyStatus `gorm:"type:varchar(20);not null"`
+	StatusCode   int                         `gorm:"type:int"`
+	ContentType  string                      `gorm:"type:varchar(255)"`
+	ResponseBody []byte                      `gorm:"type:bytea"`
+	ExpiresAt    time.Time                   `gorm:"not null;index"`
+	LockedUntil  *time.Time
+	CreatedAt    *time.Time
+	UpdatedAt    *time.Time
+}
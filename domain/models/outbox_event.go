@@ -0,0 +1,23 @@
+package models
+
+import (
+	"field-service/constants"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OutboxEvent struct {
+	ID            uint                   `gorm:"primaryKey;autoIncrement"`
+	UUID          uuid.UUID              `gorm:"type:uuid;not null;uniqueIndex"`
+	AggregateType string                 `gorm:"type:varchar(50);not null"`
+	AggregateUUID uuid.UUID              `gorm:"type:uuid;not null"`
+	EventType     constants.EventType    `gorm:"type:varchar(100);not null"`
+	Payload       JSON                   `gorm:"type:jsonb;not null"`
+	Status        constants.OutboxStatus `gorm:"type:varchar(20);not null;index:idx_outbox_events_pending"`
+	Attempts      int                    `gorm:"type:int;not null;default:0"`
+	LastError     string                 `gorm:"type:text"`
+	LockedUntil   *time.Time
+	PublishedAt   *time.Time
+	CreatedAt     *time.Time `gorm:"index:idx_outbox_events_pending"`
+}
@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+type ProcessedMessage struct {
+	ID          uint   `gorm:"primaryKey;autoIncrement"`
+	MessageID   string `gorm:"type:varchar(255);not null;uniqueIndex"`
+	Topic       string `gorm:"type:varchar(100);not null"`
+	ProcessedAt *time.Time
+}
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// ReplayKey is an accepted request signature, kept until the signature's
+// timestamp falls out of the allowed clock skew.
+type ReplayKey struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement"`
+	Key       string    `gorm:"type:varchar(64);not null;uniqueIndex"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+	CreatedAt *time.Time
+}
@@ -4,13 +4,15 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type Time struct {
 	ID        uint      `gorm:"primaryKey;autoIncrement"`
-	UUID      uuid.UUID `gorm:"type:uuid;not null"`
+	UUID      uuid.UUID `gorm:"type:uuid;not null;uniqueIndex"`
 	StartTime string    `gorm:"type:time without time zone;not null"`
 	EndTime   string    `gorm:"type:time without time zone;not null"`
 	CreatedAt *time.Time
 	UpdatedAt *time.Time
+	DeletedAt *gorm.DeletedAt `gorm:"index"`
 }
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"field-service/constants"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+type WebhookSubscription struct {
+	ID         uint           `gorm:"primaryKey;autoIncrement"`
+	UUID       uuid.UUID      `gorm:"type:uuid;not null;uniqueIndex"`
+	URL        string         `gorm:"type:varchar(500);not null"`
+	EventTypes pq.StringArray `gorm:"type:text[];not null"`
+	Secret     string         `gorm:"type:varchar(255);not null"`
+	IsActive   bool           `gorm:"not null;default:true"`
+	CreatedAt  *time.Time
+	UpdatedAt  *time.Time
+	DeletedAt  *gorm.DeletedAt
+}
+
+type WebhookDelivery struct {
+	ID             uint                            `gorm:"primaryKey;autoIncrement"`
+	UUID           uuid.UUID                       `gorm:"type:uuid;not null;uniqueIndex"`
+	SubscriptionID uint                            `gorm:"type:int;not null;index"`
+	EventID        uuid.UUID                       `gorm:"type:uuid;not null"`
+	EventType      constants.WebhookEventType      `gorm:"type:varchar(100);not null"`
+	Payload        JSON                            `gorm:"type:jsonb;not null"`
+	Status         constants.WebhookDeliveryStatus `gorm:"type:varchar(20);not null;index:idx_webhook_deliveries_due"`
+	Attempts       int                             `gorm:"type:int;not null;default:0"`
+	NextAttemptAt  time.Time                       `gorm:"not null;index:idx_webhook_deliveries_due"`
+	LastStatusCode int                             `gorm:"type:int"`
+	LastError      string                          `gorm:"type:text"`
+	DeliveredAt    *time.Time
+	CreatedAt      *time.Time
+	UpdatedAt      *time.Time
+	Subscription   WebhookSubscription `gorm:"foreignKey:subscription_id;references:id;constraint:OnDelete:CASCADE;OnUpdate:CASCADE"`
+}
@@ -0,0 +1,57 @@
+package grpcserver
+
+import (
+	"context"
+	"field-service/domain/dto"
+	"field-service/pb"
+	"field-service/services"
+)
+
+type FieldServer struct {
+	pb.UnimplementedFieldServiceServer
+	service services.IServiceRegistry
+}
+
+func NewFieldServer(service services.IServiceRegistry) pb.FieldServiceServer {
+	return &FieldServer{service: service}
+}
+
+func toFieldMessage(field *dto.InternalFieldResponse) *pb.Field {
+	return &pb.Field{
+		Uuid:         field.UUID.String(),
+		Code:         field.Code,
+		Name:         field.Name,
+		PricePerHour: int64(field.PricePerHour),
+		Images:       field.Images,
+		CreatedAt:    toTimestamp(field.CreatedAt),
+		UpdatedAt:    toTimestamp(field.UpdatedAt),
+	}
+}
+
+func (f *FieldServer) GetField(ctx context.Context, request *pb.GetFieldRequest) (*pb.Field, error) {
+	err := validateUUID("uuid", request.GetUuid())
+	if err != nil {
+		return nil, err
+	}
+
+	field, err := f.service.GetField().GetInternalByUUID(ctx, request.GetUuid())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toFieldMessage(field), nil
+}
+
+func (f *FieldServer) ListFields(ctx context.Context, _ *pb.ListFieldsRequest) (*pb.ListFieldsResponse, error) {
+	fields, err := f.service.GetField().GetAllInternal(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	response := &pb.ListFieldsResponse{Fields: make([]*pb.Field, 0, len(fields))}
+	for i := range fields {
+		response.Fields = append(response.Fields, toFieldMessage(&fields[i]))
+	}
+
+	return response, nil
+}
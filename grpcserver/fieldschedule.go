@@ -0,0 +1,127 @@
+package grpcserver
+
+import (
+	"context"
+	"field-service/constants"
+	"field-service/domain/dto"
+	"field-service/pb"
+	"field-service/services"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type FieldScheduleServer struct {
+	pb.UnimplementedFieldScheduleServiceServer
+	service services.IServiceRegistry
+}
+
+func NewFieldScheduleServer(service services.IServiceRegistry) pb.FieldScheduleServiceServer {
+	return &FieldScheduleServer{service: service}
+}
+
+var (
+	statusToMessage = map[constants.FieldScheduleStatus]pb.FieldScheduleStatus{
+		constants.Available: pb.FieldScheduleStatus_FIELD_SCHEDULE_STATUS_AVAILABLE,
+		constants.Booked:    pb.FieldScheduleStatus_FIELD_SCHEDULE_STATUS_BOOKED,
+	}
+	statusFromMessage = map[pb.FieldScheduleStatus]constants.FieldScheduleStatus{
+		pb.FieldScheduleStatus_FIELD_SCHEDULE_STATUS_AVAILABLE: constants.Available,
+		pb.FieldScheduleStatus_FIELD_SCHEDULE_STATUS_BOOKED:    constants.Booked,
+	}
+)
+
+func toFieldScheduleMessage(fieldSchedule *dto.InternalFieldScheduleResponse) *pb.FieldSchedule {
+	return &pb.FieldSchedule{
+		Uuid:         fieldSchedule.UUID.String(),
+		FieldUuid:    fieldSchedule.FieldUUID.String(),
+		FieldName:    fieldSchedule.FieldName,
+		Date:         fieldSchedule.Date.Format(time.DateOnly),
+		PricePerHour: int64(fieldSchedule.PricePerHour),
+		Status:       statusToMessage[fieldSchedule.Status],
+		Time: &pb.TimeSlot{
+			Uuid:      fieldSchedule.TimeUUID.String(),
+			StartTime: fieldSchedule.StartTime,
+			EndTime:   fieldSchedule.EndTime,
+		},
+		CreatedAt: toTimestamp(fieldSchedule.CreatedAt),
+		UpdatedAt: toTimestamp(fieldSchedule.UpdatedAt),
+	}
+}
+
+func (f *FieldScheduleServer) GetFieldSchedule(ctx context.Context, request *pb.GetFieldScheduleRequest) (*pb.FieldSchedule, error) {
+	err := validateUUID("uuid", request.GetUuid())
+	if err != nil {
+		return nil, err
+	}
+
+	fieldSchedule, err := f.service.GetFieldSchedule().GetInternalByUUID(ctx, request.GetUuid())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toFieldScheduleMessage(fieldSchedule), nil
+}
+
+func (f *FieldScheduleServer) ListFieldSchedules(ctx context.Context, request *pb.ListFieldSchedulesRequest) (*pb.ListFieldSchedulesResponse, error) {
+	err := validateUUID("field_uuid", request.GetFieldUuid())
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = time.Parse(time.DateOnly, request.GetDate())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "date must use the YYYY-MM-DD format")
+	}
+
+	fieldSchedules, err := f.service.GetFieldSchedule().GetAllInternalByFieldIDAndDate(ctx, request.GetFieldUuid(), request.GetDate())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	response := &pb.ListFieldSchedulesResponse{FieldSchedules: make([]*pb.FieldSchedule, 0, len(fieldSchedules))}
+	for i := range fieldSchedules {
+		response.FieldSchedules = append(response.FieldSchedules, toFieldScheduleMessage(&fieldSchedules[i]))
+	}
+
+	return response, nil
+}
+
+func (f *FieldScheduleServer) UpdateFieldScheduleStatus(
+	ctx context.Context,
+	request *pb.UpdateFieldScheduleStatusRequest,
+) (*pb.UpdateFieldScheduleStatusResponse, error) {
+	fieldScheduleStatus, ok := statusFromMessage[request.GetStatus()]
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "status must be available or booked")
+	}
+
+	if len(request.GetFieldScheduleUuids()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "field_schedule_uuids is required")
+	}
+
+	for _, item := range request.GetFieldScheduleUuids() {
+		err := validateUUID("field_schedule_uuids", item)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := f.service.GetFieldSchedule().SetStatus(ctx, fieldScheduleStatus, nil, request.GetFieldScheduleUuids())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	response := &pb.UpdateFieldScheduleStatusResponse{FieldSchedules: make([]*pb.FieldSchedule, 0, len(request.GetFieldScheduleUuids()))}
+	for _, item := range request.GetFieldScheduleUuids() {
+		fieldSchedule, err := f.service.GetFieldSchedule().GetInternalByUUID(ctx, item)
+		if err != nil {
+			return nil, toStatus(err)
+		}
+
+		response.FieldSchedules = append(response.FieldSchedules, toFieldScheduleMessage(fieldSchedule))
+	}
+
+	return response, nil
+}
@@ -0,0 +1,113 @@
+package grpcserver
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"field-service/common/logger"
+	"field-service/common/signature"
+	"field-service/constants"
+	errConstants "field-service/constants/error"
+	"field-service/middlewares"
+	"field-service/pb"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// signatureMethod is the method name used when matching gRPC calls against a
+// calling service's allowed routes, e.g. "GRPC /field.v1.FieldService/*".
+const signatureMethod = "GRPC"
+
+var mutatingMethods = map[string]bool{
+	pb.FieldScheduleService_UpdateFieldScheduleStatus_FullMethodName: true,
+}
+
+func metadataValue(md metadata.MD, key string) string {
+	values := md.Get(strings.ToLower(key))
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+func RecoveryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.FromContext(ctx).Errorf("Recovered from panic in %s: %v", info.FullMethod, r)
+				err = status.Error(codes.Internal, errConstants.ErrInternalServer.Error())
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+func RequestContextInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		requestID := metadataValue(md, constants.XRequestID)
+		if requestID == "" {
+			requestID = uuid.New().String()
+		}
+
+		_ = grpc.SetHeader(ctx, metadata.Pairs(strings.ToLower(constants.XRequestID), requestID))
+		ctx = context.WithValue(ctx, constants.RequestID, requestID)
+		if p, ok := peer.FromContext(ctx); ok {
+			ctx = context.WithValue(ctx, constants.SourceIP, p.Addr.String())
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// AuthInterceptor applies the same x-api-key signature check as the HTTP API,
+// reading the headers from the call metadata.
+func AuthInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		request := signature.Request{
+			ServiceName: metadataValue(md, constants.XServiceName),
+			APIKey:      metadataValue(md, constants.XApiKey),
+			RequestAt:   metadataValue(md, constants.XRequestAt),
+			Method:      signatureMethod,
+			Route:       info.FullMethod,
+			Nonce:       metadataValue(md, constants.XRequestID),
+		}
+
+		if mutatingMethods[info.FullMethod] {
+			message, ok := req.(proto.Message)
+			if ok {
+				payload, _ := proto.Marshal(message)
+				digest := sha256.Sum256(payload)
+				request.ReplayScope = fmt.Sprintf("%s:%s", info.FullMethod, hex.EncodeToString(digest[:]))
+			}
+		}
+
+		service, err := middlewares.GetSignatureVerifier().Verify(ctx, request)
+		if err != nil {
+			if errors.Is(err, errConstants.ErrInternalServer) {
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+
+			if errors.Is(err, errConstants.ErrForbiden) {
+				return nil, status.Error(codes.PermissionDenied, err.Error())
+			}
+
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+
+		ctx = context.WithValue(ctx, constants.ServiceName, service.Name)
+		return handler(ctx, req)
+	}
+}
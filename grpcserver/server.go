@@ -0,0 +1,76 @@
+package grpcserver
+
+import (
+	"errors"
+	errConstants "field-service/constants/error"
+	errField "field-service/constants/error/field"
+	errFieldSchedule "field-service/constants/error/field_schedule"
+	errTime "field-service/constants/error/time"
+	"field-service/pb"
+	"field-service/services"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func NewServer(service services.IServiceRegistry) *grpc.Server {
+	server := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(
+			RecoveryInterceptor(),
+			RequestContextInterceptor(),
+			AuthInterceptor(),
+		),
+	)
+
+	pb.RegisterFieldServiceServer(server, NewFieldServer(service))
+	pb.RegisterTimeServiceServer(server, NewTimeServer(service))
+	pb.RegisterFieldScheduleServiceServer(server, NewFieldScheduleServer(service))
+	return server
+}
+
+// toStatus maps service errors to gRPC codes. Unknown errors are hidden behind
+// the generic internal error, the same way the HTTP responses do.
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, errField.ErrFieldNotFound),
+		errors.Is(err, errFieldSchedule.ErrFieldScheduleNotFound),
+		errors.Is(err, errTime.ErrTimeNotFound),
+		errors.Is(err, errConstants.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, errFieldSchedule.ErrFieldShceduleExist):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, errConstants.ErrUnauthorized),
+		errors.Is(err, errConstants.ErrRequestExpired),
+		errors.Is(err, errConstants.ErrRequestReplayed):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, errConstants.ErrForbiden):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, errConstants.ErrSqlQuery):
+		return status.Error(codes.Unavailable, err.Error())
+	default:
+		return status.Error(codes.Internal, errConstants.ErrInternalServer.Error())
+	}
+}
+
+func validateUUID(name, value string) error {
+	_, err := uuid.Parse(value)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "%s must be a valid uuid", name)
+	}
+
+	return nil
+}
+
+func toTimestamp(value *time.Time) *timestamppb.Timestamp {
+	if value == nil {
+		return nil
+	}
+
+	return timestamppb.New(*value)
+}
@@ -0,0 +1,53 @@
+package grpcserver
+
+import (
+	"context"
+	"field-service/domain/dto"
+	"field-service/pb"
+	"field-service/services"
+)
+
+type TimeServer struct {
+	pb.UnimplementedTimeServiceServer
+	service services.IServiceRegistry
+}
+
+func NewTimeServer(service services.IServiceRegistry) pb.TimeServiceServer {
+	return &TimeServer{service: service}
+}
+
+func toTimeSlotMessage(time *dto.TimeResponse) *pb.TimeSlot {
+	return &pb.TimeSlot{
+		Uuid:      time.UUID.String(),
+		StartTime: time.StartTime,
+		EndTime:   time.EndTime,
+	}
+}
+
+func (t *TimeServer) GetTime(ctx context.Context, request *pb.GetTimeRequest) (*pb.TimeSlot, error) {
+	err := validateUUID("uuid", request.GetUuid())
+	if err != nil {
+		return nil, err
+	}
+
+	time, err := t.service.GetTime().GetByUUID(ctx, request.GetUuid())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toTimeSlotMessage(time), nil
+}
+
+func (t *TimeServer) ListTimes(ctx context.Context, _ *pb.ListTimesRequest) (*pb.ListTimesResponse, error) {
+	times, err := t.service.GetTime().GetAll(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	response := &pb.ListTimesResponse{Times: make([]*pb.TimeSlot, 0, len(times))}
+	for i := range times {
+		response.Times = append(response.Times, toTimeSlotMessage(&times[i]))
+	}
+
+	return response, nil
+}
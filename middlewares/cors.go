@@ -0,0 +1,156 @@
+package middlewares
+
+import (
+	"errors"
+	"field-service/config"
+	"field-service/constants"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const defaultCORSPolicy = "default"
+
+var errCORSAnyOriginWithCredentials = errors.New(
+	`cors: allowedOrigins must list origins explicitly when allowCredentials is set, "*" is not allowed`)
+
+var (
+	defaultAllowedMethods = []string{
+		http.MethodGet,
+		http.MethodPost,
+		http.MethodPut,
+		http.MethodPatch,
+		http.MethodDelete,
+	}
+	defaultAllowedHeaders = []string{
+		"Content-Type",
+		constants.Authorization,
+		constants.XServiceName,
+		constants.XApiKey,
+		constants.XRequestAt,
+		constants.XRequestID,
+		constants.IdempotencyKey,
+	}
+)
+
+// corsPolicy picks the policy for the running environment, falling back to "default".
+func corsPolicy() config.CORS {
+	policy, ok := config.Config.CORS[config.Config.AppEnv]
+	if !ok {
+		policy = config.Config.CORS[defaultCORSPolicy]
+	}
+
+	if len(policy.AllowedOrigins) == 0 {
+		policy.AllowedOrigins = []string{"*"}
+	}
+
+	if len(policy.AllowedMethods) == 0 {
+		policy.AllowedMethods = defaultAllowedMethods
+	}
+
+	if len(policy.AllowedHeaders) == 0 {
+		policy.AllowedHeaders = defaultAllowedHeaders
+	}
+
+	return policy
+}
+
+// validateCORSPolicy rejects a policy that would send credentials to any
+// origin: with "*" every origin is reflected back together with
+// Access-Control-Allow-Credentials.
+func validateCORSPolicy(policy config.CORS) error {
+	if policy.AllowCredentials && slices.Contains(policy.AllowedOrigins, "*") {
+		return errCORSAnyOriginWithCredentials
+	}
+
+	return nil
+}
+
+// isOriginAllowed supports exact origins, "*" and wildcard subdomains such as "https://*.example.com".
+func isOriginAllowed(origin string, allowedOrigins []string) bool {
+	for _, allowed := range allowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+
+		scheme, host, ok := strings.Cut(allowed, "://*.")
+		if !ok {
+			continue
+		}
+
+		prefix := scheme + "://"
+		if !strings.HasPrefix(origin, prefix) {
+			continue
+		}
+
+		originHost := strings.TrimPrefix(origin, prefix)
+		if strings.HasSuffix(strings.ToLower(originHost), "."+strings.ToLower(host)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CORS panics at startup when the policy for the environment is unsafe.
+func CORS() gin.HandlerFunc {
+	policy := corsPolicy()
+	err := validateCORSPolicy(policy)
+	if err != nil {
+		panic(err)
+	}
+
+	allowedMethods := strings.Join(policy.AllowedMethods, ", ")
+	allowedHeaders := strings.Join(policy.AllowedHeaders, ", ")
+	exposedHeaders := strings.Join(policy.ExposedHeaders, ", ")
+	maxAge := strconv.Itoa(policy.MaxAgeSecond)
+	allowAnyOrigin := !policy.AllowCredentials && len(policy.AllowedOrigins) == 1 && policy.AllowedOrigins[0] == "*"
+
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if origin == "" {
+			c.Next()
+			return
+		}
+
+		header := c.Writer.Header()
+		header.Add("Vary", "Origin")
+		if !isOriginAllowed(origin, policy.AllowedOrigins) {
+			if c.Request.Method == http.MethodOptions {
+				c.AbortWithStatus(http.StatusForbidden)
+				return
+			}
+			c.Next()
+			return
+		}
+
+		if allowAnyOrigin {
+			header.Set("Access-Control-Allow-Origin", "*")
+		} else {
+			header.Set("Access-Control-Allow-Origin", origin)
+		}
+
+		if policy.AllowCredentials {
+			header.Set("Access-Control-Allow-Credentials", "true")
+		}
+
+		if exposedHeaders != "" {
+			header.Set("Access-Control-Expose-Headers", exposedHeaders)
+		}
+
+		if c.Request.Method == http.MethodOptions {
+			header.Set("Access-Control-Allow-Methods", allowedMethods)
+			header.Set("Access-Control-Allow-Headers", allowedHeaders)
+			if policy.MaxAgeSecond > 0 {
+				header.Set("Access-Control-Max-Age", maxAge)
+			}
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+
+		c.Next()
+	}
+}
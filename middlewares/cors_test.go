@@ -0,0 +1,117 @@
+package middlewares
+
+import (
+	"errors"
+	"field-service/config"
+	"testing"
+)
+
+func TestIsOriginAllowed(t *testing.T) {
+	tests := []struct {
+		name           string
+		origin         string
+		allowedOrigins []string
+		want           bool
+	}{
+		{
+			name:           "any origin",
+			origin:         "https://app.example.com",
+			allowedOrigins: []string{"*"},
+			want:           true,
+		},
+		{
+			name:           "exact origin",
+			origin:         "https://app.example.com",
+			allowedOrigins: []string{"https://app.example.com"},
+			want:           true,
+		},
+		{
+			name:           "exact origin ignores case",
+			origin:         "https://APP.example.com",
+			allowedOrigins: []string{"https://app.example.com"},
+			want:           true,
+		},
+		{
+			name:           "other origin",
+			origin:         "https://evil.com",
+			allowedOrigins: []string{"https://app.example.com"},
+			want:           false,
+		},
+		{
+			name:           "wildcard subdomain",
+			origin:         "https://app.example.com",
+			allowedOrigins: []string{"https://*.example.com"},
+			want:           true,
+		},
+		{
+			name:           "wildcard nested subdomain",
+			origin:         "https://a.b.example.com",
+			allowedOrigins: []string{"https://*.example.com"},
+			want:           true,
+		},
+		{
+			name:           "wildcard does not match the bare domain",
+			origin:         "https://example.com",
+			allowedOrigins: []string{"https://*.example.com"},
+			want:           false,
+		},
+		{
+			name:           "wildcard does not match a lookalike domain",
+			origin:         "https://evilexample.com",
+			allowedOrigins: []string{"https://*.example.com"},
+			want:           false,
+		},
+		{
+			name:           "wildcard checks the scheme",
+			origin:         "http://app.example.com",
+			allowedOrigins: []string{"https://*.example.com"},
+			want:           false,
+		},
+		{
+			name:           "no allowed origins",
+			origin:         "https://app.example.com",
+			allowedOrigins: nil,
+			want:           false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := isOriginAllowed(tt.origin, tt.allowedOrigins)
+			if got != tt.want {
+				t.Errorf("isOriginAllowed(%q, %v) = %v, want %v", tt.origin, tt.allowedOrigins, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateCORSPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  config.CORS
+		wantErr error
+	}{
+		{
+			name:   "any origin without credentials",
+			policy: config.CORS{AllowedOrigins: []string{"*"}},
+		},
+		{
+			name:   "listed origins with credentials",
+			policy: config.CORS{AllowedOrigins: []string{"https://*.example.com"}, AllowCredentials: true},
+		},
+		{
+			name:    "any origin with credentials",
+			policy:  config.CORS{AllowedOrigins: []string{"https://app.example.com", "*"}, AllowCredentials: true},
+			wantErr: errCORSAnyOriginWithCredentials,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCORSPolicy(tt.policy)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("validateCORSPolicy() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,246 @@
+package middlewares
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"field-service/common/logger"
+	"field-service/common/response"
+	"field-service/constants"
+	errConstants "field-service/constants/error"
+	errIdempotency "field-service/constants/error/idempotency"
+	"field-service/domain/models"
+	idempotencyRepo "field-service/repositories/idempotency"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	maxIdempotencyKeyLength        = 255
+	defaultIdempotencyTTL          = 24 * time.Hour
+	defaultIdempotencyLockDuration = time.Minute
+)
+
+type idempotencyRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *idempotencyRecorder) Write(data []byte) (int, error) {
+	r.body.Write(data)
+	return r.ResponseWriter.Write(data)
+}
+
+func (r *idempotencyRecorder) WriteString(data string) (int, error) {
+	r.body.WriteString(data)
+	return r.ResponseWriter.WriteString(data)
+}
+
+func isIdempotentMethod(method string) bool {
+	return method == http.MethodPost || method == http.MethodPut || method == http.MethodPatch
+}
+
+// shouldStoreResponse keeps final answers only. Server errors and rejections
+// that depend on the credentials or timing of one attempt are retried for real.
+func shouldStoreResponse(status int) bool {
+	switch status {
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusRequestTimeout, http.StatusTooManyRequests:
+		return false
+	}
+
+	return status < http.StatusInternalServerError
+}
+
+// idempotencyCaller scopes keys to the verified calling service and user, as set
+// by the authentication middlewares, so two callers never read each other's
+// stored responses.
+func idempotencyCaller(c *gin.Context) string {
+	return fmt.Sprintf("%s:%s", c.GetString(constants.ServiceName), c.GetString(constants.UserUUID))
+}
+
+// hashMultipart hashes the parts rather than the raw body, because clients
+// generate a new boundary every time they resend the same form.
+func hashMultipart(body []byte, boundary string) (string, error) {
+	reader := multipart.NewReader(bytes.NewReader(body), boundary)
+	parts := make([]string, 0)
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+
+		content := sha256.New()
+		_, err = io.Copy(content, part)
+		if err != nil {
+			return "", err
+		}
+
+		parts = append(parts, fmt.Sprintf("%s:%s:%x", part.FormName(), part.FileName(), content.Sum(nil)))
+	}
+
+	sort.Strings(parts)
+	return strings.Join(parts, "\n"), nil
+}
+
+func hashRequest(c *gin.Context) (string, error) {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return "", err
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	content := string(body)
+	mediaType, params, err := mime.ParseMediaType(c.GetHeader("Content-Type"))
+	if err == nil && mediaType == "multipart/form-data" && params["boundary"] != "" {
+		content, err = hashMultipart(body, params["boundary"])
+		if err != nil {
+			return "", err
+		}
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(c.Request.Method + "\n" + c.Request.URL.Path + "\n" + c.Request.URL.RawQuery + "\n"))
+	hash.Write([]byte(content))
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func responseIdempotencyError(c *gin.Context, code int, err error) {
+	c.JSON(code, response.Response{
+		Status:  constants.Error,
+		Message: err.Error(),
+	})
+	c.Abort()
+}
+
+var (
+	idempotencyRepository   idempotencyRepo.IIdempotencyRepository
+	idempotencyTTL          = defaultIdempotencyTTL
+	idempotencyLockDuration = defaultIdempotencyLockDuration
+)
+
+// InitIdempotency sets where Idempotency stores responses. Without it the
+// middleware lets every request through. lockDuration bounds how long a key
+// stays in progress, so it must outlast the slowest request.
+func InitIdempotency(repository idempotencyRepo.IIdempotencyRepository, ttl, lockDuration time.Duration) {
+	idempotencyRepository = repository
+	idempotencyTTL = defaultIdempotencyTTL
+	if ttl > 0 {
+		idempotencyTTL = ttl
+	}
+
+	idempotencyLockDuration = defaultIdempotencyLockDuration
+	if lockDuration > 0 {
+		idempotencyLockDuration = lockDuration
+	}
+}
+
+// Idempotency replays the stored response when a POST, PUT or PATCH request is
+// retried with the same Idempotency-Key. Requests without the header pass through.
+// It must come after the authentication and permission middlewares of a route,
+// so a replay is only served to a caller that passes them again.
+func Idempotency() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		repository, ttl, lockDuration := idempotencyRepository, idempotencyTTL, idempotencyLockDuration
+		if repository == nil {
+			c.Next()
+			return
+		}
+
+		key := c.GetHeader(constants.IdempotencyKey)
+		if key == "" || !isIdempotentMethod(c.Request.Method) {
+			c.Next()
+			return
+		}
+
+		if len(key) > maxIdempotencyKeyLength {
+			responseIdempotencyError(c, http.StatusBadRequest, errIdempotency.ErrIdempotencyKeyInvalid)
+			return
+		}
+
+		requestHash, err := hashRequest(c)
+		if err != nil {
+			logger.FromContext(c.Request.Context()).Errorf("failed to hash idempotent request: %v", err)
+			responseIdempotencyError(c, http.StatusInternalServerError, errConstants.ErrInternalServer)
+			return
+		}
+
+		// Finishing the record must not depend on the client staying connected.
+		ctx := context.WithoutCancel(c.Request.Context())
+		now := time.Now()
+		lockedUntil := now.Add(lockDuration)
+		record := &models.IdempotencyKey{
+			Key:         key,
+			Caller:      idempotencyCaller(c),
+			Method:      c.Request.Method,
+			Path:        c.Request.URL.Path,
+			RequestHash: requestHash,
+			Status:      constants.IdempotencyProcessing,
+			ExpiresAt:   now.Add(ttl),
+			LockedUntil: &lockedUntil,
+		}
+
+		existing, reserved, err := repository.Reserve(ctx, record)
+		if err != nil {
+			responseIdempotencyError(c, http.StatusInternalServerError, errConstants.ErrInternalServer)
+			return
+		}
+
+		if !reserved {
+			if existing.RequestHash != requestHash {
+				responseIdempotencyError(c, http.StatusUnprocessableEntity, errIdempotency.ErrIdempotencyKeyReused)
+				return
+			}
+
+			if existing.Status != constants.IdempotencyCompleted {
+				responseIdempotencyError(c, http.StatusConflict, errIdempotency.ErrIdempotencyKeyInProgress)
+				return
+			}
+
+			c.Header(constants.IdempotencyReplayed, "true")
+			c.Data(existing.StatusCode, existing.ContentType, existing.ResponseBody)
+			c.Abort()
+			return
+		}
+
+		defer func() {
+			if r := recover(); r != nil {
+				_ = repository.Release(ctx, record)
+				panic(r)
+			}
+		}()
+
+		recorder := &idempotencyRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		status := recorder.Status()
+		if !shouldStoreResponse(status) {
+			err = repository.Release(ctx, record)
+			if err != nil {
+				logger.FromContext(ctx).Errorf("failed to release idempotency key %q: %v", key, err)
+			}
+			return
+		}
+
+		record.StatusCode = status
+		record.ContentType = recorder.Header().Get("Content-Type")
+		record.ResponseBody = recorder.body.Bytes()
+		err = repository.Complete(ctx, record)
+		if err != nil {
+			logger.FromContext(ctx).Errorf("failed to store response for idempotency key %q: %v", key, err)
+		}
+	}
+}
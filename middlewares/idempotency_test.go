@@ -0,0 +1,284 @@
+package middlewares
+
+import (
+	"context"
+	"field-service/constants"
+	"field-service/domain/models"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// memoryIdempotencyRepository keeps idempotency keys in a map, keyed like the
+// unique index on key and caller.
+type memoryIdempotencyRepository struct {
+	mutex   sync.Mutex
+	records map[string]models.IdempotencyKey
+}
+
+func newMemoryIdempotencyRepository(records ...models.IdempotencyKey) *memoryIdempotencyRepository {
+	repository := &memoryIdempotencyRepository{records: map[string]models.IdempotencyKey{}}
+	for _, record := range records {
+		repository.records[record.Key+"|"+record.Caller] = record
+	}
+
+	return repository
+}
+
+func (m *memoryIdempotencyRepository) Reserve(_ context.Context, request *models.IdempotencyKey) (*models.IdempotencyKey, bool, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	now := time.Now()
+	existing, ok := m.records[request.Key+"|"+request.Caller]
+	abandoned := existing.Status == constants.IdempotencyProcessing &&
+		existing.LockedUntil != nil && existing.LockedUntil.Before(now)
+	if ok && existing.ExpiresAt.After(now) && !abandoned {
+		return &existing, false, nil
+	}
+
+	m.records[request.Key+"|"+request.Caller] = *request
+	return request, true, nil
+}
+
+func (m *memoryIdempotencyRepository) Complete(_ context.Context, request *models.IdempotencyKey) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	record := *request
+	record.Status = constants.IdempotencyCompleted
+	m.records[request.Key+"|"+request.Caller] = record
+	return nil
+}
+
+func (m *memoryIdempotencyRepository) Release(_ context.Context, request *models.IdempotencyKey) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	delete(m.records, request.Key+"|"+request.Caller)
+	return nil
+}
+
+func (m *memoryIdempotencyRepository) DeleteExpired(context.Context) (int64, error) {
+	return 0, nil
+}
+
+type idempotentCall struct {
+	method     string
+	key        string
+	caller     string
+	body       string
+	status     int
+	wantStatus int
+	wantBody   string
+	replayed   bool
+}
+
+// newIdempotencyRouter stands in for a route: the caller header plays the part
+// of the authentication middlewares and the handler numbers its responses.
+func newIdempotencyRouter(calls *int) *gin.Engine {
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(constants.ServiceName, "order-service")
+		c.Set(constants.UserUUID, c.GetHeader("X-Test-Caller"))
+	}, Idempotency())
+
+	handler := func(c *gin.Context) {
+		*calls++
+		status, _ := strconv.Atoi(c.GetHeader("X-Test-Status"))
+		c.String(status, "response %d", *calls)
+	}
+	router.POST("/fields", handler)
+	router.GET("/fields", handler)
+	return router
+}
+
+func timePointer(value time.Time) *time.Time {
+	return &value
+}
+
+func requestHash(t *testing.T, method, body string) string {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(method, "/fields", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	hash, err := hashRequest(c)
+	if err != nil {
+		t.Fatalf("hashRequest() error = %v", err)
+	}
+
+	return hash
+}
+
+func TestIdempotency(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name      string
+		existing  []models.IdempotencyKey
+		calls     []idempotentCall
+		wantCalls int
+	}{
+		{
+			name: "retry replays the stored response",
+			calls: []idempotentCall{
+				{method: http.MethodPost, key: "k1", caller: "u1", body: `{"a":1}`, status: http.StatusCreated,
+					wantStatus: http.StatusCreated, wantBody: "response 1"},
+				{method: http.MethodPost, key: "k1", caller: "u1", body: `{"a":1}`, status: http.StatusCreated,
+					wantStatus: http.StatusCreated, wantBody: "response 1", replayed: true},
+			},
+			wantCalls: 1,
+		},
+		{
+			name: "key reused with another body",
+			calls: []idempotentCall{
+				{method: http.MethodPost, key: "k1", caller: "u1", body: `{"a":1}`, status: http.StatusCreated,
+					wantStatus: http.StatusCreated, wantBody: "response 1"},
+				{method: http.MethodPost, key: "k1", caller: "u1", body: `{"a":2}`, status: http.StatusCreated,
+					wantStatus: http.StatusUnprocessableEntity},
+			},
+			wantCalls: 1,
+		},
+		{
+			name: "same key from another caller",
+			calls: []idempotentCall{
+				{method: http.MethodPost, key: "k1", caller: "u1", body: `{"a":1}`, status: http.StatusCreated,
+					wantStatus: http.StatusCreated, wantBody: "response 1"},
+				{method: http.MethodPost, key: "k1", caller: "u2", body: `{"a":1}`, status: http.StatusCreated,
+					wantStatus: http.StatusCreated, wantBody: "response 2"},
+			},
+			wantCalls: 2,
+		},
+		{
+			name: "request still in progress",
+			existing: []models.IdempotencyKey{{
+				Key:         "k1",
+				Caller:      "order-service:u1",
+				RequestHash: requestHash(t, http.MethodPost, `{"a":1}`),
+				Status:      constants.IdempotencyProcessing,
+				ExpiresAt:   time.Now().Add(time.Hour),
+				LockedUntil: timePointer(time.Now().Add(time.Minute)),
+			}},
+			calls: []idempotentCall{
+				{method: http.MethodPost, key: "k1", caller: "u1", body: `{"a":1}`, status: http.StatusCreated,
+					wantStatus: http.StatusConflict},
+			},
+			wantCalls: 0,
+		},
+		{
+			name: "reservation abandoned by a crashed instance",
+			existing: []models.IdempotencyKey{{
+				Key:         "k1",
+				Caller:      "order-service:u1",
+				RequestHash: requestHash(t, http.MethodPost, `{"a":1}`),
+				Status:      constants.IdempotencyProcessing,
+				ExpiresAt:   time.Now().Add(time.Hour),
+				LockedUntil: timePointer(time.Now().Add(-time.Second)),
+			}},
+			calls: []idempotentCall{
+				{method: http.MethodPost, key: "k1", caller: "u1", body: `{"a":1}`, status: http.StatusCreated,
+					wantStatus: http.StatusCreated, wantBody: "response 1"},
+			},
+			wantCalls: 1,
+		},
+		{
+			name: "server errors are not stored",
+			calls: []idempotentCall{
+				{method: http.MethodPost, key: "k1", caller: "u1", body: `{"a":1}`, status: http.StatusInternalServerError,
+					wantStatus: http.StatusInternalServerError, wantBody: "response 1"},
+				{method: http.MethodPost, key: "k1", caller: "u1", body: `{"a":1}`, status: http.StatusCreated,
+					wantStatus: http.StatusCreated, wantBody: "response 2"},
+			},
+			wantCalls: 2,
+		},
+		{
+			name: "requests without a key run every time",
+			calls: []idempotentCall{
+				{method: http.MethodPost, caller: "u1", body: `{"a":1}`, status: http.StatusCreated,
+					wantStatus: http.StatusCreated, wantBody: "response 1"},
+				{method: http.MethodPost, caller: "u1", body: `{"a":1}`, status: http.StatusCreated,
+					wantStatus: http.StatusCreated, wantBody: "response 2"},
+			},
+			wantCalls: 2,
+		},
+		{
+			name: "reads ignore the key",
+			calls: []idempotentCall{
+				{method: http.MethodGet, key: "k1", caller: "u1", status: http.StatusOK,
+					wantStatus: http.StatusOK, wantBody: "response 1"},
+				{method: http.MethodGet, key: "k1", caller: "u1", status: http.StatusOK,
+					wantStatus: http.StatusOK, wantBody: "response 2"},
+			},
+			wantCalls: 2,
+		},
+		{
+			name: "key too long",
+			calls: []idempotentCall{
+				{method: http.MethodPost, key: strings.Repeat("k", maxIdempotencyKeyLength+1), caller: "u1", status: http.StatusCreated,
+					wantStatus: http.StatusBadRequest},
+			},
+			wantCalls: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			InitIdempotency(newMemoryIdempotencyRepository(tt.existing...), time.Hour, time.Minute)
+			t.Cleanup(func() { InitIdempotency(nil, 0, 0) })
+
+			var calls int
+			router := newIdempotencyRouter(&calls)
+			for i, item := range tt.calls {
+				request := httptest.NewRequest(item.method, "/fields", strings.NewReader(item.body))
+				request.Header.Set("Content-Type", "application/json")
+				request.Header.Set("X-Test-Caller", item.caller)
+				request.Header.Set("X-Test-Status", strconv.Itoa(item.status))
+				if item.key != "" {
+					request.Header.Set(constants.IdempotencyKey, item.key)
+				}
+
+				recorder := httptest.NewRecorder()
+				router.ServeHTTP(recorder, request)
+
+				if recorder.Code != item.wantStatus {
+					t.Errorf("call %d: status = %d, want %d", i+1, recorder.Code, item.wantStatus)
+				}
+				if item.wantBody != "" && recorder.Body.String() != item.wantBody {
+					t.Errorf("call %d: body = %q, want %q", i+1, recorder.Body.String(), item.wantBody)
+				}
+				if replayed := recorder.Header().Get(constants.IdempotencyReplayed) == "true"; replayed != item.replayed {
+					t.Errorf("call %d: replayed = %v, want %v", i+1, replayed, item.replayed)
+				}
+			}
+
+			if calls != tt.wantCalls {
+				t.Errorf("handler ran %d times, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestIdempotencyWithoutRepository(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	InitIdempotency(nil, 0, 0)
+
+	var calls int
+	router := newIdempotencyRouter(&calls)
+	for i := 0; i < 2; i++ {
+		request := httptest.NewRequest(http.MethodPost, "/fields", strings.NewReader(`{"a":1}`))
+		request.Header.Set(constants.IdempotencyKey, "k1")
+		request.Header.Set("X-Test-Status", fmt.Sprint(http.StatusCreated))
+		router.ServeHTTP(httptest.NewRecorder(), request)
+	}
+
+	if calls != 2 {
+		t.Errorf("handler ran %d times, want 2", calls)
+	}
+}
@@ -2,28 +2,35 @@ package middlewares
 
 import (
 	"context"
-	"crypto/sha256"
-	"encoding/hex"
+	"errors"
 	"field-service/clients"
+	clientUser "field-service/clients/users"
+	"field-service/common/logger"
+	"field-service/common/metrics"
+	"field-service/common/rbac"
 	"field-service/common/response"
+	"field-service/common/signature"
+	"field-service/common/token"
 	"field-service/config"
 	"field-service/constants"
 	errConstants "field-service/constants/error"
 	"fmt"
 	"net/http"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/didip/tollbooth"
 	"github.com/didip/tollbooth/limiter"
 	"github.com/gin-gonic/gin"
-	"github.com/sirupsen/logrus"
+	"github.com/google/uuid"
 )
 
 func HandlePanic() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		defer func() {
 			if r := recover(); r != nil {
-				logrus.Errorf("Recovered from panic: %v", r)
+				logger.FromContext(c.Request.Context()).Errorf("Recovered from panic: %v", r)
 				c.JSON(http.StatusInternalServerError, response.Response{
 					Status:  constants.Error,
 					Message: errConstants.ErrInternalServer.Error(),
@@ -39,6 +46,7 @@ func RateLimiter(lmt *limiter.Limiter) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		err := tollbooth.LimitByRequest(lmt, c.Writer, c.Request)
 		if err != nil {
+			metrics.RateLimiterRejectionsTotal.Inc()
 			c.JSON(http.StatusTooManyRequests, response.Response{
 				Status:  constants.Error,
 				Message: errConstants.ErrTooManyRequest.Error(),
@@ -49,6 +57,25 @@ func RateLimiter(lmt *limiter.Limiter) gin.HandlerFunc {
 	}
 }
 
+func setContextValue(c *gin.Context, key string, value any) {
+	c.Set(key, value)
+	c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), key, value))
+}
+
+func RequestContext() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(constants.XRequestID)
+		if requestID == "" {
+			requestID = uuid.New().String()
+		}
+
+		c.Writer.Header().Set(constants.XRequestID, requestID)
+		setContextValue(c, constants.RequestID, requestID)
+		setContextValue(c, constants.SourceIP, c.ClientIP())
+		c.Next()
+	}
+}
+
 func extractBearerToken(token string) string {
 	arrayToken := strings.Split(token, " ")
 
@@ -67,54 +94,185 @@ func responseUnauthorized(c *gin.Context, message string) {
 	c.Abort()
 }
 
+// statusClientClosedRequest is recorded when the client hung up before it
+// could be answered, so it is not counted as an upstream failure.
+const statusClientClosedRequest = 499
+
+var (
+	signatureVerifier     signature.IVerifier
+	signatureVerifierOnce sync.Once
+	signatureReplayStore  signature.ReplayStore
+)
+
+// InitSignatureReplayStore sets where accepted signatures are remembered. It
+// must run before the first request is verified; without it each instance only
+// catches replays it saw itself.
+func InitSignatureReplayStore(store signature.ReplayStore) {
+	signatureReplayStore = store
+}
+
+// GetSignatureVerifier is shared by the HTTP middleware and the gRPC interceptors.
+func GetSignatureVerifier() signature.IVerifier {
+	signatureVerifierOnce.Do(func() {
+		services := make([]signature.Service, 0, len(config.Config.CallingServices))
+		for _, service := range config.Config.CallingServices {
+			services = append(services, signature.Service{
+				Name:          service.Name,
+				SignatureKeys: service.SignatureKeys,
+				AllowedRoutes: service.AllowedRoutes,
+				Revoked:       service.Revoked,
+			})
+		}
+
+		signatureVerifier = signature.NewVerifier(
+			services,
+			config.Config.SignatureKey,
+			time.Duration(config.Config.SignatureClockSkewSecond)*time.Second,
+			signatureReplayStore,
+		)
+	})
+
+	return signatureVerifier
+}
+
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
 func validateAPIKey(c *gin.Context) error {
-	apiKey := c.GetHeader(constants.XApiKey)
-	requestAt := c.GetHeader(constants.XRequestAt)
-	serviceName := c.GetHeader(constants.XServiceName)
-	signatureKey := config.Config.SignatureKey
+	request := signature.Request{
+		ServiceName: c.GetHeader(constants.XServiceName),
+		APIKey:      c.GetHeader(constants.XApiKey),
+		RequestAt:   c.GetHeader(constants.XRequestAt),
+		Method:      c.Request.Method,
+		Route:       c.FullPath(),
+		Nonce:       c.GetHeader(constants.XRequestID),
+	}
 
-	validateKey := fmt.Sprintf("%s:%s:%s", serviceName, signatureKey, requestAt)
-	hash := sha256.New()
-	hash.Write([]byte(validateKey))
-	resultHash := hex.EncodeToString(hash.Sum(nil))
+	// Without a nonce the signature only has second granularity, so reads issued
+	// within the same second legitimately share it. Only state-changing requests
+	// are checked, and the body is part of the scope so distinct calls to the
+	// same route within one second are not mistaken for replays.
+	if !isSafeMethod(c.Request.Method) {
+		requestHash, err := hashRequest(c)
+		if err != nil {
+			return errConstants.ErrUnauthorized
+		}
 
-	logrus.Infof("API KEY %s", resultHash)
+		request.ReplayScope = fmt.Sprintf("%s:%s:%s",
+			c.GetHeader(constants.Authorization),
+			c.Request.URL.Path,
+			requestHash,
+		)
+	}
 
-	if apiKey != resultHash {
-		logrus.Info("Api key didn't same like result hash")
-		return errConstants.ErrUnauthorized
+	service, err := GetSignatureVerifier().Verify(c.Request.Context(), request)
+	if err != nil {
+		return err
 	}
 
+	setContextValue(c, constants.ServiceName, service.Name)
 	return nil
 }
 
-func contains(roles []string, role string) bool {
-	for _, r := range roles {
-		if r == role {
-			return true
-		}
+func responseAPIKeyError(c *gin.Context, err error) {
+	if errors.Is(err, errConstants.ErrInternalServer) {
+		c.JSON(http.StatusInternalServerError, response.Response{
+			Status:  constants.Error,
+			Message: err.Error(),
+		})
+		c.Abort()
+		return
+	}
+
+	if errors.Is(err, errConstants.ErrForbiden) {
+		c.JSON(http.StatusForbidden, response.Response{
+			Status:  constants.Error,
+			Message: err.Error(),
+		})
+		c.Abort()
+		return
 	}
 
-	return false
+	responseUnauthorized(c, err.Error())
 }
 
-func CheckRole(roles []string, clients clients.IClientRegistry) gin.HandlerFunc {
+func getAuthenticatedUser(c *gin.Context, clients clients.IClientRegistry) (*clientUser.UserData, error) {
+	user, ok := c.Request.Context().Value(constants.User).(*clientUser.UserData)
+	if ok {
+		return user, nil
+	}
+
+	return clients.GetUser().GetUserByToken(c.Request.Context())
+}
+
+func CheckPermission(permission string, clients clients.IClientRegistry) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		user, err := clients.GetUser().GetUserByToken(c.Request.Context())
+		user, err := getAuthenticatedUser(c, clients)
 		if err != nil {
+			// The client hung up; there is nobody to answer.
+			if errors.Is(err, context.Canceled) {
+				c.AbortWithStatus(statusClientClosedRequest)
+				return
+			}
+			if errors.Is(err, errConstants.ErrAuthUpstreamUnavailable) {
+				c.JSON(http.StatusServiceUnavailable, response.Response{
+					Status:  constants.Error,
+					Message: err.Error(),
+				})
+				c.Abort()
+				return
+			}
 			responseUnauthorized(c, errConstants.ErrUnauthorized.Error())
 			return
 		}
 
-		if !contains(roles, user.Role) {
-			responseUnauthorized(c, errConstants.ErrUnauthorized.Error())
+		setContextValue(c, constants.User, user)
+		setContextValue(c, constants.UserUUID, user.UUID.String())
+		if !rbac.Policy.HasPermission(user.Role, permission) {
+			c.JSON(http.StatusForbidden, response.Response{
+				Status:  constants.Error,
+				Message: errConstants.ErrForbiden.Error(),
+			})
+			c.Abort()
 			return
 		}
 		c.Next()
 	}
 }
 
+var (
+	tokenVerifier     token.ITokenVerifier
+	tokenVerifierOnce sync.Once
+)
+
+func getTokenVerifier() token.ITokenVerifier {
+	tokenVerifierOnce.Do(func() {
+		if config.Config.JWT.Mode != constants.AuthModeLocal {
+			return
+		}
+
+		verifier, err := token.NewTokenVerifier(token.Config{
+			HMACSecret:    config.Config.JWT.HMACSecret,
+			PublicKeyFile: config.Config.JWT.PublicKeyFile,
+			JWKSFile:      config.Config.JWT.JWKSFile,
+			Issuer:        config.Config.JWT.Issuer,
+			Audience:      config.Config.JWT.Audience,
+			UUIDClaim:     config.Config.JWT.UUIDClaim,
+			RoleClaim:     config.Config.JWT.RoleClaim,
+		})
+		if err != nil {
+			panic(err)
+		}
+
+		tokenVerifier = verifier
+	})
+
+	return tokenVerifier
+}
+
 func Authenticate() gin.HandlerFunc {
+	verifier := getTokenVerifier()
 	return func(c *gin.Context) {
 
 		var err error
@@ -126,15 +284,29 @@ func Authenticate() gin.HandlerFunc {
 
 		err = validateAPIKey(c)
 		if err != nil {
-			responseUnauthorized(c, err.Error())
+			responseAPIKeyError(c, err)
 			return
 		}
 
 		tokenString := extractBearerToken(token)
-		tokenUser := c.Request.WithContext(context.WithValue(c.Request.Context(), constants.Token, tokenString))
-		c.Request = tokenUser
-		c.Next()
+		ctx := context.WithValue(c.Request.Context(), constants.Token, tokenString)
+		if verifier != nil {
+			claims, err := verifier.Verify(tokenString)
+			if err != nil {
+				logger.FromContext(c.Request.Context()).Warnf("failed to verify jwt locally: %v", err)
+				if !config.Config.JWT.FallbackToUserService {
+					responseUnauthorized(c, errConstants.ErrInvalidToken.Error())
+					return
+				}
+			} else {
+				ctx = context.WithValue(ctx, constants.User, &clientUser.UserData{
+					UUID: claims.UUID,
+					Role: claims.Role,
+				})
+			}
+		}
 
+		c.Request = c.Request.WithContext(ctx)
 		c.Next()
 	}
 }
@@ -143,7 +315,7 @@ func AuthenticateWithoutToken() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		err := validateAPIKey(c)
 		if err != nil {
-			responseUnauthorized(c, err.Error())
+			responseAPIKeyError(c, err)
 			return
 		}
 		c.Next()
@@ -0,0 +1,60 @@
+package middlewares
+
+import (
+	"field-service/common/util"
+	"field-service/config"
+	"field-service/constants"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestAuthenticateWithoutTokenReplay(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	config.Config.SignatureClockSkewSecond = 60
+	config.Config.CallingServices = []config.CallingService{{
+		Name:          "order-service",
+		SignatureKeys: []string{"key"},
+		AllowedRoutes: []string{"*"},
+	}}
+
+	router := gin.New()
+	router.PATCH("/api/v1/field/schedule/status", AuthenticateWithoutToken(), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	requestAt := strconv.FormatInt(time.Now().Unix(), 10)
+	send := func(body string) int {
+		request := httptest.NewRequest(http.MethodPatch, "/api/v1/field/schedule/status", strings.NewReader(body))
+		request.Header.Set("Content-Type", "application/json")
+		request.Header.Set(constants.XServiceName, "order-service")
+		request.Header.Set(constants.XRequestAt, requestAt)
+		request.Header.Set(constants.XApiKey, util.GenerateSHA256(fmt.Sprintf("order-service:key:%s", requestAt)))
+
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		return recorder.Code
+	}
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+	}{
+		{name: "first call", body: `{"orderID":"a"}`, wantStatus: http.StatusOK},
+		{name: "another body within the same second", body: `{"orderID":"b"}`, wantStatus: http.StatusOK},
+		{name: "same body replayed", body: `{"orderID":"a"}`, wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		if got := send(tt.body); got != tt.wantStatus {
+			t.Errorf("%s: status = %d, want %d", tt.name, got, tt.wantStatus)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+-- Baseline matching the schema previously created by AutoMigrate. Every statement
+-- is guarded so databases that already have these tables can adopt migrations.
+
+CREATE TABLE IF NOT EXISTS fields (
+    id             BIGSERIAL PRIMARY KEY,
+    uuid           UUID         NOT NULL,
+    code           VARCHAR(15)  NOT NULL,
+    name           VARCHAR(200) NOT NULL,
+    price_per_hour INT          NOT NULL,
+    images         TEXT[]       NOT NULL,
+    created_at     TIMESTAMPTZ,
+    updated_at     TIMESTAMPTZ,
+    deleted_at     TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS times (
+    id         BIGSERIAL PRIMARY KEY,
+    uuid       UUID                   NOT NULL,
+    start_time TIME WITHOUT TIME ZONE NOT NULL,
+    end_time   TIME WITHOUT TIME ZONE NOT NULL,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS field_schedules (
+    id         BIGSERIAL PRIMARY KEY,
+    uuid       UUID NOT NULL,
+    field_id   INT  NOT NULL,
+    time_id    INT  NOT NULL,
+    date       DATE NOT NULL,
+    status     INT  NOT NULL,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    CONSTRAINT fk_field_schedules_field FOREIGN KEY (field_id)
+        REFERENCES fields (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_field_schedules_time FOREIGN KEY (time_id)
+        REFERENCES times (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id           BIGSERIAL PRIMARY KEY,
+    uuid         UUID        NOT NULL,
+    actor_uuid   UUID,
+    actor_name   VARCHAR(200),
+    actor_role   VARCHAR(50),
+    service_name VARCHAR(100),
+    action       VARCHAR(50) NOT NULL,
+    entity       VARCHAR(50) NOT NULL,
+    entity_uuid  UUID        NOT NULL,
+    before       JSONB,
+    after        JSONB,
+    diff         JSONB,
+    request_id   VARCHAR(100),
+    source_ip    VARCHAR(50),
+    created_at   TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_actor_uuid ON audit_logs (actor_uuid);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_action ON audit_logs (action);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_entity ON audit_logs (entity, entity_uuid);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_request_id ON audit_logs (request_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_created_at ON audit_logs (created_at);
+
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id          BIGSERIAL PRIMARY KEY,
+    uuid        UUID         NOT NULL,
+    url         VARCHAR(500) NOT NULL,
+    event_types TEXT[]       NOT NULL,
+    secret      VARCHAR(255) NOT NULL,
+    is_active   BOOLEAN      NOT NULL DEFAULT TRUE,
+    created_at  TIMESTAMPTZ,
+    updated_at  TIMESTAMPTZ,
+    deleted_at  TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id               BIGSERIAL PRIMARY KEY,
+    uuid             UUID         NOT NULL,
+    subscription_id  INT          NOT NULL,
+    event_id         UUID         NOT NULL,
+    event_type       VARCHAR(100) NOT NULL,
+    payload          JSONB        NOT NULL,
+    status           VARCHAR(20)  NOT NULL,
+    attempts         INT          NOT NULL DEFAULT 0,
+    next_attempt_at  TIMESTAMPTZ  NOT NULL,
+    last_status_code INT,
+    last_error       TEXT,
+    delivered_at     TIMESTAMPTZ,
+    created_at       TIMESTAMPTZ,
+    updated_at       TIMESTAMPTZ,
+    CONSTRAINT fk_webhook_deliveries_subscription FOREIGN KEY (subscription_id)
+        REFERENCES webhook_subscriptions (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription_id ON webhook_deliveries (subscription_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries (status, next_attempt_at);
+
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id             BIGSERIAL PRIMARY KEY,
+    uuid           UUID         NOT NULL,
+    aggregate_type VARCHAR(50)  NOT NULL,
+    aggregate_uuid UUID         NOT NULL,
+    event_type     VARCHAR(100) NOT NULL,
+    payload        JSONB        NOT NULL,
+    status         VARCHAR(20)  NOT NULL,
+    attempts       INT          NOT NULL DEFAULT 0,
+    last_error     TEXT,
+    published_at   TIMESTAMPTZ,
+    created_at     TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_events_pending ON outbox_events (status, created_at);
+
+CREATE TABLE IF NOT EXISTS processed_messages (
+    id           BIGSERIAL PRIMARY KEY,
+    message_id   VARCHAR(255) NOT NULL,
+    topic        VARCHAR(100) NOT NULL,
+    processed_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_processed_messages_message_id ON processed_messages (message_id);
+
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    id            BIGSERIAL PRIMARY KEY,
+    key           VARCHAR(255) NOT NULL,
+    caller        VARCHAR(255) NOT NULL,
+    method        VARCHAR(10)  NOT NULL,
+    path          VARCHAR(500) NOT NULL,
+    request_hash  VARCHAR(64)  NOT NULL,
+    status        VARCHAR(20)  NOT NULL,
+    status_code   INT,
+    content_type  VARCHAR(255),
+    response_body BYTEA,
+    expires_at    TIMESTAMPTZ  NOT NULL,
+    created_at    TIMESTAMPTZ,
+    updated_at    TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_idempotency_keys_key_caller ON idempotency_keys (key, caller);
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
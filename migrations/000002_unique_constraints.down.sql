@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_outbox_events_uuid;
+DROP INDEX IF EXISTS idx_webhook_deliveries_uuid;
+DROP INDEX IF EXISTS idx_webhook_subscriptions_uuid;
+DROP INDEX IF EXISTS idx_audit_logs_uuid;
+DROP INDEX IF EXISTS idx_field_schedules_uuid;
+DROP INDEX IF EXISTS idx_times_uuid;
+DROP INDEX IF EXISTS idx_fields_uuid;
+DROP INDEX IF EXISTS idx_field_schedules_slot;
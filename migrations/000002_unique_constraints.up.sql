@@ -0,0 +1,28 @@
+-- Concurrent creates could insert the same slot twice. Drop the extra available
+-- copies, keeping a booked row if there is one and otherwise the oldest. Booked
+-- duplicates are left alone so the index below fails and they get fixed by hand.
+DELETE FROM field_schedules
+WHERE id IN (
+    SELECT id
+    FROM (
+        SELECT id,
+               status,
+               row_number() OVER (PARTITION BY field_id, date, time_id ORDER BY status DESC, id) AS position
+        FROM field_schedules
+        WHERE deleted_at IS NULL
+    ) duplicates
+    WHERE duplicates.position > 1
+      AND duplicates.status = 100
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_field_schedules_slot
+    ON field_schedules (field_id, date, time_id)
+    WHERE deleted_at IS NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_fields_uuid ON fields (uuid);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_times_uuid ON times (uuid);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_field_schedules_uuid ON field_schedules (uuid);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_audit_logs_uuid ON audit_logs (uuid);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_webhook_subscriptions_uuid ON webhook_subscriptions (uuid);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_webhook_deliveries_uuid ON webhook_deliveries (uuid);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_outbox_events_uuid ON outbox_events (uuid);
@@ -0,0 +1,9 @@
+-- Rows in the trash would silently come back as live rows, so they are removed.
+DELETE FROM field_schedules WHERE deleted_at IS NOT NULL;
+DELETE FROM times WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_times_deleted_at;
+DROP INDEX IF EXISTS idx_field_schedules_deleted_at;
+DROP INDEX IF EXISTS idx_fields_deleted_at;
+
+ALTER TABLE times DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,5 @@
+ALTER TABLE times ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_fields_deleted_at ON fields (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_field_schedules_deleted_at ON field_schedules (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_times_deleted_at ON times (deleted_at);
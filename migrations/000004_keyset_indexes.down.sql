@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_field_schedules_keyset;
+DROP INDEX IF EXISTS idx_fields_keyset;
@@ -0,0 +1,8 @@
+-- Keyset pages walk the live rows in creation order, id breaking ties.
+CREATE INDEX IF NOT EXISTS idx_fields_keyset
+    ON fields (created_at, id)
+    WHERE deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_field_schedules_keyset
+    ON field_schedules (created_at, id)
+    WHERE deleted_at IS NULL;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_fields_code_trgm;
+DROP INDEX IF EXISTS idx_fields_name_trgm;
+DROP INDEX IF EXISTS idx_fields_search_vector;
+ALTER TABLE fields DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,14 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- The simple configuration keeps field names searchable as typed instead of
+-- stemming them as English. Name matches weigh more than code matches; new
+-- text columns such as a description or venue belong in this expression too.
+ALTER TABLE fields ADD COLUMN IF NOT EXISTS search_vector TSVECTOR
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+        setweight(to_tsvector('simple', coalesce(code, '')), 'B')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_fields_search_vector ON fields USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_fields_name_trgm ON fields USING GIN (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_fields_code_trgm ON fields USING GIN (code gin_trgm_ops);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS dead_letter_messages (
+    id         BIGSERIAL PRIMARY KEY,
+    message_id VARCHAR(255) NOT NULL,
+    topic      VARCHAR(100) NOT NULL,
+    payload    BYTEA        NOT NULL,
+    attempts   INT          NOT NULL DEFAULT 0,
+    last_error TEXT,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_dead_letter_messages_message_id ON dead_letter_messages (message_id);
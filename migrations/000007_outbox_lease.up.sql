@@ -0,0 +1,2 @@
+-- Relays lease the events they are publishing instead of holding row locks.
+ALTER TABLE outbox_events ADD COLUMN IF NOT EXISTS locked_until TIMESTAMPTZ;
@@ -0,0 +1,10 @@
+-- Request signatures accepted by any instance, so a replay is caught wherever it lands.
+CREATE TABLE IF NOT EXISTS replay_keys (
+    id         BIGSERIAL PRIMARY KEY,
+    key        VARCHAR(64) NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_replay_keys_key ON replay_keys (key);
+CREATE INDEX IF NOT EXISTS idx_replay_keys_expires_at ON replay_keys (expires_at);
@@ -0,0 +1 @@
+ALTER TABLE field_schedules DROP COLUMN IF EXISTS order_uuid;
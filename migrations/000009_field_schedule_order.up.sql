@@ -0,0 +1,2 @@
+-- The order holding a booked slot, so a cancel or expire event only frees its own slots.
+ALTER TABLE field_schedules ADD COLUMN IF NOT EXISTS order_uuid UUID;
@@ -0,0 +1,3 @@
+-- A processing key is only held until locked_until, so one left behind by a
+-- crashed instance can be taken over before its TTL ends.
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS locked_until TIMESTAMPTZ;
@@ -0,0 +1,12 @@
+// Package migrations holds the versioned SQL migrations of the service. Files are
+// named <version>_<name>.up.sql and <version>_<name>.down.sql and are embedded in
+// the binary, so a deployed image always carries the schema it expects.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var Files embed.FS
+
+// Dir is where `migrate create` writes new files, relative to the repository root.
+const Dir = "migrations"
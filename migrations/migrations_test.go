@@ -0,0 +1,22 @@
+package migrations
+
+import (
+	"field-service/common/migration"
+	"testing"
+)
+
+func TestFilesAreNumberedInSequence(t *testing.T) {
+	loaded, err := migration.Load(Files)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	for i, item := range loaded {
+		if item.Version != uint64(i+1) {
+			t.Errorf("migration %d_%s is at position %d, want version %d", item.Version, item.Name, i, i+1)
+		}
+		if item.Down == "" {
+			t.Errorf("migration %d_%s has no down file", item.Version, item.Name)
+		}
+	}
+}
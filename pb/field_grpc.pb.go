@@ -0,0 +1,477 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: field.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	FieldService_GetField_FullMethodName   = "/field.v1.FieldService/GetField"
+	FieldService_ListFields_FullMethodName = "/field.v1.FieldService/ListFields"
+)
+
+// FieldServiceClient is the client API for FieldService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type FieldServiceClient interface {
+	GetField(ctx context.Context, in *GetFieldRequest, opts ...grpc.CallOption) (*Field, error)
+	ListFields(ctx context.Context, in *ListFieldsRequest, opts ...grpc.CallOption) (*ListFieldsResponse, error)
+}
+
+type fieldServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFieldServiceClient(cc grpc.ClientConnInterface) FieldServiceClient {
+	return &fieldServiceClient{cc}
+}
+
+func (c *fieldServiceClient) GetField(ctx context.Context, in *GetFieldRequest, opts ...grpc.CallOption) (*Field, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Field)
+	err := c.cc.Invoke(ctx, FieldService_GetField_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fieldServiceClient) ListFields(ctx context.Context, in *ListFieldsRequest, opts ...grpc.CallOption) (*ListFieldsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFieldsResponse)
+	err := c.cc.Invoke(ctx, FieldService_ListFields_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FieldServiceServer is the server API for FieldService service.
+// All implementations must embed UnimplementedFieldServiceServer
+// for forward compatibility.
+type FieldServiceServer interface {
+	GetField(context.Context, *GetFieldRequest) (*Field, error)
+	ListFields(context.Context, *ListFieldsRequest) (*ListFieldsResponse, error)
+	mustEmbedUnimplementedFieldServiceServer()
+}
+
+// UnimplementedFieldServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedFieldServiceServer struct{}
+
+func (UnimplementedFieldServiceServer) GetField(context.Context, *GetFieldRequest) (*Field, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetField not implemented")
+}
+func (UnimplementedFieldServiceServer) ListFields(context.Context, *ListFieldsRequest) (*ListFieldsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFields not implemented")
+}
+func (UnimplementedFieldServiceServer) mustEmbedUnimplementedFieldServiceServer() {}
+func (UnimplementedFieldServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeFieldServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FieldServiceServer will
+// result in compilation errors.
+type UnsafeFieldServiceServer interface {
+	mustEmbedUnimplementedFieldServiceServer()
+}
+
+func RegisterFieldServiceServer(s grpc.ServiceRegistrar, srv FieldServiceServer) {
+	// If the following call pancis, it indicates UnimplementedFieldServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&FieldService_ServiceDesc, srv)
+}
+
+func _FieldService_GetField_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFieldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FieldServiceServer).GetField(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FieldService_GetField_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FieldServiceServer).GetField(ctx, req.(*GetFieldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FieldService_ListFields_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFieldsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FieldServiceServer).ListFields(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FieldService_ListFields_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FieldServiceServer).ListFields(ctx, req.(*ListFieldsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FieldService_ServiceDesc is the grpc.ServiceDesc for FieldService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FieldService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "field.v1.FieldService",
+	HandlerType: (*FieldServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetField",
+			Handler:    _FieldService_GetField_Handler,
+		},
+		{
+			MethodName: "ListFields",
+			Handler:    _FieldService_ListFields_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "field.proto",
+}
+
+const (
+	TimeService_GetTime_FullMethodName   = "/field.v1.TimeService/GetTime"
+	TimeService_ListTimes_FullMethodName = "/field.v1.TimeService/ListTimes"
+)
+
+// TimeServiceClient is the client API for TimeService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TimeServiceClient interface {
+	GetTime(ctx context.Context, in *GetTimeRequest, opts ...grpc.CallOption) (*TimeSlot, error)
+	ListTimes(ctx context.Context, in *ListTimesRequest, opts ...grpc.CallOption) (*ListTimesResponse, error)
+}
+
+type timeServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTimeServiceClient(cc grpc.ClientConnInterface) TimeServiceClient {
+	return &timeServiceClient{cc}
+}
+
+func (c *timeServiceClient) GetTime(ctx context.Context, in *GetTimeRequest, opts ...grpc.CallOption) (*TimeSlot, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TimeSlot)
+	err := c.cc.Invoke(ctx, TimeService_GetTime_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *timeServiceClient) ListTimes(ctx context.Context, in *ListTimesRequest, opts ...grpc.CallOption) (*ListTimesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTimesResponse)
+	err := c.cc.Invoke(ctx, TimeService_ListTimes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TimeServiceServer is the server API for TimeService service.
+// All implementations must embed UnimplementedTimeServiceServer
+// for forward compatibility.
+type TimeServiceServer interface {
+	GetTime(context.Context, *GetTimeRequest) (*TimeSlot, error)
+	ListTimes(context.Context, *ListTimesRequest) (*ListTimesResponse, error)
+	mustEmbedUnimplementedTimeServiceServer()
+}
+
+// UnimplementedTimeServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTimeServiceServer struct{}
+
+func (UnimplementedTimeServiceServer) GetTime(context.Context, *GetTimeRequest) (*TimeSlot, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTime not implemented")
+}
+func (UnimplementedTimeServiceServer) ListTimes(context.Context, *ListTimesRequest) (*ListTimesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTimes not implemented")
+}
+func (UnimplementedTimeServiceServer) mustEmbedUnimplementedTimeServiceServer() {}
+func (UnimplementedTimeServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeTimeServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TimeServiceServer will
+// result in compilation errors.
+type UnsafeTimeServiceServer interface {
+	mustEmbedUnimplementedTimeServiceServer()
+}
+
+func RegisterTimeServiceServer(s grpc.ServiceRegistrar, srv TimeServiceServer) {
+	// If the following call pancis, it indicates UnimplementedTimeServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TimeService_ServiceDesc, srv)
+}
+
+func _TimeService_GetTime_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTimeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TimeServiceServer).GetTime(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TimeService_GetTime_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TimeServiceServer).GetTime(ctx, req.(*GetTimeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TimeService_ListTimes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTimesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TimeServiceServer).ListTimes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TimeService_ListTimes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TimeServiceServer).ListTimes(ctx, req.(*ListTimesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TimeService_ServiceDesc is the grpc.ServiceDesc for TimeService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TimeService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "field.v1.TimeService",
+	HandlerType: (*TimeServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetTime",
+			Handler:    _TimeService_GetTime_Handler,
+		},
+		{
+			MethodName: "ListTimes",
+			Handler:    _TimeService_ListTimes_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "field.proto",
+}
+
+const (
+	FieldScheduleService_GetFieldSchedule_FullMethodName          = "/field.v1.FieldScheduleService/GetFieldSchedule"
+	FieldScheduleService_ListFieldSchedules_FullMethodName        = "/field.v1.FieldScheduleService/ListFieldSchedules"
+	FieldScheduleService_UpdateFieldScheduleStatus_FullMethodName = "/field.v1.FieldScheduleService/UpdateFieldScheduleStatus"
+)
+
+// FieldScheduleServiceClient is the client API for FieldScheduleService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type FieldScheduleServiceClient interface {
+	GetFieldSchedule(ctx context.Context, in *GetFieldScheduleRequest, opts ...grpc.CallOption) (*FieldSchedule, error)
+	ListFieldSchedules(ctx context.Context, in *ListFieldSchedulesRequest, opts ...grpc.CallOption) (*ListFieldSchedulesResponse, error)
+	UpdateFieldScheduleStatus(ctx context.Context, in *UpdateFieldScheduleStatusRequest, opts ...grpc.CallOption) (*UpdateFieldScheduleStatusResponse, error)
+}
+
+type fieldScheduleServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFieldScheduleServiceClient(cc grpc.ClientConnInterface) FieldScheduleServiceClient {
+	return &fieldScheduleServiceClient{cc}
+}
+
+func (c *fieldScheduleServiceClient) GetFieldSchedule(ctx context.Context, in *GetFieldScheduleRequest, opts ...grpc.CallOption) (*FieldSchedule, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FieldSchedule)
+	err := c.cc.Invoke(ctx, FieldScheduleService_GetFieldSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fieldScheduleServiceClient) ListFieldSchedules(ctx context.Context, in *ListFieldSchedulesRequest, opts ...grpc.CallOption) (*ListFieldSchedulesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFieldSchedulesResponse)
+	err := c.cc.Invoke(ctx, FieldScheduleService_ListFieldSchedules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fieldScheduleServiceClient) UpdateFieldScheduleStatus(ctx context.Context, in *UpdateFieldScheduleStatusRequest, opts ...grpc.CallOption) (*UpdateFieldScheduleStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateFieldScheduleStatusResponse)
+	err := c.cc.Invoke(ctx, FieldScheduleService_UpdateFieldScheduleStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FieldScheduleServiceServer is the server API for FieldScheduleService service.
+// All implementations must embed UnimplementedFieldScheduleServiceServer
+// for forward compatibility.
+type FieldScheduleServiceServer interface {
+	GetFieldSchedule(context.Context, *GetFieldScheduleRequest) (*FieldSchedule, error)
+	ListFieldSchedules(context.Context, *ListFieldSchedulesRequest) (*ListFieldSchedulesResponse, error)
+	UpdateFieldScheduleStatus(context.Context, *UpdateFieldScheduleStatusRequest) (*UpdateFieldScheduleStatusResponse, error)
+	mustEmbedUnimplementedFieldScheduleServiceServer()
+}
+
+// UnimplementedFieldScheduleServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedFieldScheduleServiceServer struct{}
+
+func (UnimplementedFieldScheduleServiceServer) GetFieldSchedule(context.Context, *GetFieldScheduleRequest) (*FieldSchedule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFieldSchedule not implemented")
+}
+func (UnimplementedFieldScheduleServiceServer) ListFieldSchedules(context.Context, *ListFieldSchedulesRequest) (*ListFieldSchedulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFieldSchedules not implemented")
+}
+func (UnimplementedFieldScheduleServiceServer) UpdateFieldScheduleStatus(context.Context, *UpdateFieldScheduleStatusRequest) (*UpdateFieldScheduleStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateFieldScheduleStatus not implemented")
+}
+func (UnimplementedFieldScheduleServiceServer) mustEmbedUnimplementedFieldScheduleServiceServer() {}
+func (UnimplementedFieldScheduleServiceServer) testEmbeddedByValue()                              {}
+
+// UnsafeFieldScheduleServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FieldScheduleServiceServer will
+// result in compilation errors.
+type UnsafeFieldScheduleServiceServer interface {
+	mustEmbedUnimplementedFieldScheduleServiceServer()
+}
+
+func RegisterFieldScheduleServiceServer(s grpc.ServiceRegistrar, srv FieldScheduleServiceServer) {
+	// If the following call pancis, it indicates UnimplementedFieldScheduleServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&FieldScheduleService_ServiceDesc, srv)
+}
+
+func _FieldScheduleService_GetFieldSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFieldScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FieldScheduleServiceServer).GetFieldSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FieldScheduleService_GetFieldSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FieldScheduleServiceServer).GetFieldSchedule(ctx, req.(*GetFieldScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FieldScheduleService_ListFieldSchedules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFieldSchedulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FieldScheduleServiceServer).ListFieldSchedules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FieldScheduleService_ListFieldSchedules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FieldScheduleServiceServer).ListFieldSchedules(ctx, req.(*ListFieldSchedulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FieldScheduleService_UpdateFieldScheduleStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateFieldScheduleStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FieldScheduleServiceServer).UpdateFieldScheduleStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FieldScheduleService_UpdateFieldScheduleStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FieldScheduleServiceServer).UpdateFieldScheduleStatus(ctx, req.(*UpdateFieldScheduleStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FieldScheduleService_ServiceDesc is the grpc.ServiceDesc for FieldScheduleService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FieldScheduleService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "field.v1.FieldScheduleService",
+	HandlerType: (*FieldScheduleServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetFieldSchedule",
+			Handler:    _FieldScheduleService_GetFieldSchedule_Handler,
+		},
+		{
+			MethodName: "ListFieldSchedules",
+			Handler:    _FieldScheduleService_ListFieldSchedules_Handler,
+		},
+		{
+			MethodName: "UpdateFieldScheduleStatus",
+			Handler:    _FieldScheduleService_UpdateFieldScheduleStatus_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "field.proto",
+}
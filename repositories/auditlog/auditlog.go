@@ -0,0 +1,102 @@
+package repositories
+
+import (
+	"context"
+	errorWrap "field-service/common/error"
+	"field-service/constants"
+	errConstants "field-service/constants/error"
+	"field-service/domain/dto"
+	"field-service/domain/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+type AuditLogRepository struct {
+	db *gorm.DB
+}
+
+type IAuditLogRepository interface {
+	FindAllWithPagination(context.Context, *dto.AuditLogRequestParam) ([]models.AuditLog, int64, error)
+	Create(context.Context, []models.AuditLog) error
+}
+
+func NewAuditLogRepository(db *gorm.DB) IAuditLogRepository {
+	return &AuditLogRepository{db: db}
+}
+
+func (a *AuditLogRepository) filter(query *gorm.DB, params *dto.AuditLogRequestParam) *gorm.DB {
+	if params.ActorUUID != nil {
+		query = query.Where("actor_uuid = ?", *params.ActorUUID)
+	}
+
+	if params.Action != nil {
+		query = query.Where("action = ?", *params.Action)
+	}
+
+	if params.Entity != nil {
+		query = query.Where("entity = ?", *params.Entity)
+	}
+
+	if params.EntityUUID != nil {
+		query = query.Where("entity_uuid = ?", *params.EntityUUID)
+	}
+
+	if params.RequestID != nil {
+		query = query.Where("request_id = ?", *params.RequestID)
+	}
+
+	if params.StartDate != nil {
+		startDate, _ := time.ParseInLocation(time.DateOnly, *params.StartDate, time.Local)
+		query = query.Where("created_at >= ?", startDate)
+	}
+
+	if params.EndDate != nil {
+		endDate, _ := time.ParseInLocation(time.DateOnly, *params.EndDate, time.Local)
+		query = query.Where("created_at < ?", endDate.AddDate(0, 0, 1))
+	}
+
+	return query
+}
+
+func (a *AuditLogRepository) FindAllWithPagination(ctx context.Context, params *dto.AuditLogRequestParam) ([]models.AuditLog, int64, error) {
+	var (
+		auditLogs []models.AuditLog
+		total     int64
+	)
+
+	limit := params.Limit
+	offset := (params.Page - 1) * params.Limit
+	err := a.filter(a.db.WithContext(ctx).Clauses(dbresolver.Use(constants.ReadReplica)), params).
+		Limit(limit).
+		Offset(offset).
+		Order("created_at desc").
+		Find(&auditLogs).Error
+
+	if err != nil {
+		return nil, 0, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	err = a.filter(a.db.WithContext(ctx).Clauses(dbresolver.Use(constants.ReadReplica)).Model(&models.AuditLog{}), params).
+		Count(&total).Error
+
+	if err != nil {
+		return nil, 0, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	return auditLogs, total, nil
+}
+
+func (a *AuditLogRepository) Create(ctx context.Context, auditLogs []models.AuditLog) error {
+	if len(auditLogs) == 0 {
+		return nil
+	}
+
+	err := a.db.WithContext(ctx).Create(&auditLogs).Error
+	if err != nil {
+		return errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	return nil
+}
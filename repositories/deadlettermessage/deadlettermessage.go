@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"context"
+	errorWrap "field-service/common/error"
+	errConstants "field-service/constants/error"
+	"field-service/domain/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DeadLetterMessageRepository struct {
+	db *gorm.DB
+}
+
+type IDeadLetterMessageRepository interface {
+	FindAll(context.Context, int) ([]models.DeadLetterMessage, error)
+	Save(context.Context, *models.DeadLetterMessage) error
+	Delete(context.Context, uint) error
+}
+
+func NewDeadLetterMessageRepository(db *gorm.DB) IDeadLetterMessageRepository {
+	return &DeadLetterMessageRepository{db: db}
+}
+
+func (d *DeadLetterMessageRepository) FindAll(ctx context.Context, limit int) ([]models.DeadLetterMessage, error) {
+	var messages []models.DeadLetterMessage
+	err := d.db.WithContext(ctx).
+		Order("id asc").
+		Limit(limit).
+		Find(&messages).Error
+	if err != nil {
+		return nil, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	return messages, nil
+}
+
+// Save adds the message, or counts another failed round when it is already there.
+func (d *DeadLetterMessageRepository) Save(ctx context.Context, message *models.DeadLetterMessage) error {
+	err := d.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "message_id"}},
+			DoUpdates: clause.Assignments(map[string]any{
+				"attempts":   gorm.Expr("dead_letter_messages.attempts + EXCLUDED.attempts"),
+				"last_error": gorm.Expr("EXCLUDED.last_error"),
+				"updated_at": gorm.Expr("EXCLUDED.updated_at"),
+			}),
+		}).
+		Create(message).Error
+	if err != nil {
+		return errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	return nil
+}
+
+func (d *DeadLetterMessageRepository) Delete(ctx context.Context, id uint) error {
+	err := d.db.WithContext(ctx).Delete(&models.DeadLetterMessage{}, id).Error
+	if err != nil {
+		return errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	return nil
+}
@@ -2,85 +2,278 @@ package repositories
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"field-service/common/cursor"
 	errorWrap "field-service/common/error"
+	"field-service/common/query"
+	"field-service/constants"
 	errConstants "field-service/constants/error"
 	errField "field-service/constants/error/field"
+	errFieldSchedule "field-service/constants/error/field_schedule"
 	"field-service/domain/dto"
 	"field-service/domain/models"
+	outboxRepo "field-service/repositories/outbox"
 	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
 )
 
+// scheduleSlotIndex keeps a field from having two live schedules for the same
+// date and time.
+const scheduleSlotIndex = "idx_field_schedules_slot"
+
 type FieldRepository struct {
 	db *gorm.DB
 }
 
 type IFieldRepository interface {
 	FindAllWithPagination(context.Context, *dto.FieldRequestParam) ([]models.Field, int64, error)
+	FindAllWithCursor(context.Context, *dto.FieldRequestParam) ([]models.Field, string, error)
+	Count(context.Context, *dto.FieldRequestParam) (int64, error)
 	FindAllWithoutPagination(context.Context) ([]models.Field, error)
+	Search(context.Context, *dto.FieldSearchRequestParam) ([]dto.FieldSearchResult, int64, error)
 	FindByUUID(context.Context, string) (*models.Field, error)
 	Create(context.Context, *models.Field) (*models.Field, error)
 	Update(context.Context, string, *models.Field) (*models.Field, error)
 	Delete(context.Context, string) error
+	FindAllTrashed(context.Context, *dto.TrashRequestParam) ([]models.Field, int64, error)
+	FindTrashedByUUID(context.Context, string) (*models.Field, error)
+	Restore(context.Context, string) error
+	Purge(context.Context, time.Time) (int64, error)
 }
 
 func NewFieldRepository(db *gorm.DB) IFieldRepository {
 	return &FieldRepository{db: db}
 }
 
+// sortSpec is every order the field listings accept.
+var sortSpec = query.Spec[models.Field]{
+	Sorts: map[string]query.Sort[models.Field]{
+		"name": {Column: "fields.name", Value: func(field models.Field) string {
+			return field.Name
+		}},
+		"code": {Column: "fields.code", Value: func(field models.Field) string {
+			return field.Code
+		}},
+		"price_per_hour": {Column: "fields.price_per_hour", Value: func(field models.Field) string {
+			return strconv.Itoa(field.PricePerHour)
+		}},
+		"created_at": {Column: "fields.created_at", Value: func(field models.Field) string {
+			return cursor.FormatTime(field.CreatedAt)
+		}},
+		"updated_at": {Column: "fields.updated_at", Value: func(field models.Field) string {
+			return cursor.FormatTime(field.UpdatedAt)
+		}},
+	},
+	Default: []string{"created_at"},
+	ID: query.Sort[models.Field]{Column: "fields.id", Value: func(field models.Field) string {
+		return strconv.FormatUint(uint64(field.ID), 10)
+	}},
+}
+
+// cursorScope ties field cursors to this listing.
+const cursorScope = "fields"
+
+func (f *FieldRepository) filter(db *gorm.DB, params *dto.FieldRequestParam) *gorm.DB {
+	if params.Name != nil && *params.Name != "" {
+		term := query.Contains(*params.Name)
+		db = db.Where("fields.name ILIKE ? OR fields.code ILIKE ?", term, term)
+	}
+
+	if params.MinPrice != nil {
+		db = db.Where("fields.price_per_hour >= ?", *params.MinPrice)
+	}
+
+	if params.MaxPrice != nil {
+		db = db.Where("fields.price_per_hour <= ?", *params.MaxPrice)
+	}
+
+	return db
+}
+
 func (f *FieldRepository) FindAllWithPagination(ctx context.Context, params *dto.FieldRequestParam) ([]models.Field, int64, error) {
 	var (
 		fields []models.Field
-		sort   string
 		total  int64
 	)
 
-	if params.SortColumn != nil {
-		sort = fmt.Sprintf("%s %s", *params.SortColumn, *params.SortOrder)
-	} else {
-		sort = "created_at desc"
+	keys, err := sortSpec.Keys(params.SortColumn, params.SortOrder)
+	if err != nil {
+		return nil, 0, err
 	}
 
 	limit := params.Limit
 	offset := (params.Page - 1) * params.Limit
-	err := f.db.WithContext(ctx).
+	err = f.filter(f.db.WithContext(ctx).Clauses(dbresolver.Use(constants.ReadReplica)), params).
 		Limit(limit).
 		Offset(offset).
-		Order(sort).
+		Order(cursor.Order(keys)).
 		Find(&fields).Error
 
 	if err != nil {
-		return nil, 0, errorWrap.WrapError(errConstants.ErrSqlQuery)
+		return nil, 0, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
 	}
 
-	err = f.db.WithContext(ctx).
-		Model(&fields).Count(&total).Error
-
+	total, err = f.Count(ctx, params)
 	if err != nil {
-		return nil, 0, errorWrap.WrapError(errConstants.ErrSqlQuery)
+		return nil, 0, err
 	}
 
 	return fields, total, nil
 }
 
+func (f *FieldRepository) FindAllWithCursor(ctx context.Context, params *dto.FieldRequestParam) ([]models.Field, string, error) {
+	var fields []models.Field
+
+	keys, err := sortSpec.Keys(params.SortColumn, params.SortOrder)
+	if err != nil {
+		return nil, "", err
+	}
+
+	db := f.filter(f.db.WithContext(ctx).Clauses(dbresolver.Use(constants.ReadReplica)), params)
+	if params.Cursor != nil {
+		values, err := cursor.Decode(cursorScope, keys, *params.Cursor)
+		if err != nil {
+			return nil, "", err
+		}
+
+		condition, args := cursor.After(keys, values)
+		db = db.Where(condition, args...)
+	}
+
+	// One extra row tells whether another page follows.
+	err = db.
+		Order(cursor.Order(keys)).
+		Limit(params.Limit + 1).
+		Find(&fields).Error
+	if err != nil {
+		return nil, "", errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	if len(fields) <= params.Limit {
+		return fields, "", nil
+	}
+
+	fields = fields[:params.Limit]
+	next := cursor.Encode(cursorScope, keys, sortSpec.Values(keys, fields[len(fields)-1]))
+
+	return fields, next, nil
+}
+
+func (f *FieldRepository) Count(ctx context.Context, params *dto.FieldRequestParam) (int64, error) {
+	var total int64
+	err := f.filter(f.db.WithContext(ctx).Clauses(dbresolver.Use(constants.ReadReplica)).Model(&models.Field{}), params).
+		Count(&total).Error
+	if err != nil {
+		return 0, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	return total, nil
+}
+
 func (f *FieldRepository) FindAllWithoutPagination(ctx context.Context) ([]models.Field, error) {
 	var fields []models.Field
 
 	err := f.db.
 		WithContext(ctx).
+		Clauses(dbresolver.Use(constants.ReadReplica)).
 		Find(&fields).
 		Error
 
 	if err != nil {
-		return nil, errorWrap.WrapError(errConstants.ErrSqlQuery)
+		return nil, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
 	}
 
 	return fields, nil
 }
 
+// prefixQuery turns free text into a tsquery matching any of its words as a
+// prefix, so half-typed words still match and rank grows with every word found.
+func prefixQuery(term string) string {
+	words := strings.FieldsFunc(strings.ToLower(term), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	prefixes := make([]string, 0, len(words))
+	for _, word := range words {
+		// Single letters, like the s of an apostrophe, would match nearly everything.
+		if utf8.RuneCountInString(word) > 1 {
+			prefixes = append(prefixes, word+":*")
+		}
+	}
+
+	return strings.Join(prefixes, " | ")
+}
+
+// ts_headline copies the stored text as is, so matches are delimited with
+// control characters and turned into <mark> tags only after escaping.
+const (
+	snippetStart = "\x02"
+	snippetStop  = "\x03"
+)
+
+// markSnippet makes a ts_headline snippet safe to render as HTML.
+func markSnippet(snippet string) string {
+	return strings.NewReplacer(snippetStart, "<mark>", snippetStop, "</mark>").
+		Replace(html.EscapeString(snippet))
+}
+
+// Search ranks fields by full-text relevance on search_vector plus trigram word
+// similarity on name and code, which keeps misspelled terms matching.
+func (f *FieldRepository) Search(ctx context.Context, params *dto.FieldSearchRequestParam) ([]dto.FieldSearchResult, int64, error) {
+	var (
+		results []dto.FieldSearchResult
+		total   int64
+	)
+
+	args := []any{
+		sql.Named("term", strings.TrimSpace(params.Query)),
+		sql.Named("query", prefixQuery(params.Query)),
+		sql.Named("snippet", fmt.Sprintf("StartSel=%s, StopSel=%s", snippetStart, snippetStop)),
+	}
+	db := f.db.WithContext(ctx).
+		Clauses(dbresolver.Use(constants.ReadReplica)).
+		Model(&models.Field{}).
+		Where("fields.search_vector @@ to_tsquery('simple', @query) OR "+
+			"@term <% fields.name OR @term <% fields.code", args...)
+
+	err := db.Session(&gorm.Session{}).Count(&total).Error
+	if err != nil {
+		return nil, 0, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	limit := params.Limit
+	offset := (params.Page - 1) * params.Limit
+	err = db.
+		Select("fields.uuid, fields.code, fields.name, fields.price_per_hour, fields.images, "+
+			"fields.created_at, fields.updated_at, "+
+			"ts_rank(fields.search_vector, to_tsquery('simple', @query)) + "+
+			"greatest(word_similarity(@term, fields.name), word_similarity(@term, fields.code)) AS rank, "+
+			"ts_headline('simple', concat_ws(' ', fields.name, fields.code), to_tsquery('simple', @query), "+
+			"@snippet) AS snippet", args...).
+		Order("rank desc, fields.id").
+		Limit(limit).
+		Offset(offset).
+		Scan(&results).Error
+	if err != nil {
+		return nil, 0, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	for i := range results {
+		results[i].Snippet = markSnippet(results[i].Snippet)
+	}
+
+	return results, total, nil
+}
+
 func (f *FieldRepository) FindByUUID(ctx context.Context, uuid string) (*models.Field, error) {
 	var field models.Field
 
@@ -89,10 +282,10 @@ func (f *FieldRepository) FindByUUID(ctx context.Context, uuid string) (*models.
 
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errorWrap.WrapError(errField.ErrFieldNotFound)
+			return nil, errorWrap.WrapErrorContext(ctx, errField.ErrFieldNotFound)
 		}
 
-		return nil, errorWrap.WrapError(errConstants.ErrSqlQuery)
+		return nil, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
 	}
 
 	return &field, nil
@@ -109,7 +302,7 @@ func (f *FieldRepository) Create(ctx context.Context, request *models.Field) (*m
 
 	err := f.db.WithContext(ctx).Create(&field).Error
 	if err != nil {
-		return nil, errorWrap.WrapError(errConstants.ErrSqlQuery)
+		return nil, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
 	}
 
 	return &field, nil
@@ -123,27 +316,162 @@ func (f *FieldRepository) Update(ctx context.Context, uuid string, request *mode
 		PricePerHour: request.PricePerHour,
 	}
 
-	err := f.db.WithContext(ctx).Where("uuid = ?", uuid).Updates(&field).Error
+	err := f.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("uuid = ?", uuid).Updates(&field).Error
+		if err != nil {
+			return err
+		}
+
+		var updated models.Field
+		err = tx.Where("uuid = ?", uuid).First(&updated).Error
+		if err != nil {
+			return err
+		}
+
+		event, err := outboxRepo.NewEvent(constants.EventFieldUpdated, constants.AggregateField, updated.UUID, dto.FieldEvent{
+			UUID:         updated.UUID,
+			Code:         updated.Code,
+			Name:         updated.Name,
+			PricePerHour: updated.PricePerHour,
+			Images:       updated.Images,
+		})
+		if err != nil {
+			return err
+		}
+
+		return outboxRepo.NewOutboxRepository(tx).Create(ctx, event)
+	})
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errorWrap.WrapError(errField.ErrFieldNotFound)
+			return nil, errorWrap.WrapErrorContext(ctx, errField.ErrFieldNotFound)
 		}
 
-		return nil, errorWrap.WrapError(errConstants.ErrSqlQuery)
+		return nil, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
 	}
 
 	return &field, nil
 }
 
+// Delete trashes the field together with its live schedules, so none of them
+// can still be booked. They share one deleted_at, which is how Restore tells
+// them apart from schedules that were trashed on their own.
 func (f *FieldRepository) Delete(ctx context.Context, uuid string) error {
-	err := f.db.WithContext(ctx).Where("uuid = ?", uuid).Delete(&models.Field{}).Error
+	err := f.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var field models.Field
+		err := tx.Where("uuid = ?", uuid).First(&field).Error
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		err = tx.Model(&models.FieldSchedule{}).
+			Where("field_id = ?", field.ID).
+			UpdateColumn("deleted_at", now).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(&field).UpdateColumn("deleted_at", now).Error
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errorWrap.WrapErrorContext(ctx, errField.ErrFieldNotFound)
+		}
+
+		return errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	return nil
+}
+
+func (f *FieldRepository) FindAllTrashed(ctx context.Context, params *dto.TrashRequestParam) ([]models.Field, int64, error) {
+	var (
+		fields []models.Field
+		total  int64
+	)
+
+	query := f.db.WithContext(ctx).Unscoped().Model(&models.Field{}).Where("deleted_at IS NOT NULL").
+		Session(&gorm.Session{})
+	err := query.Count(&total).Error
+	if err != nil {
+		return nil, 0, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	err = query.
+		Order("deleted_at desc").
+		Limit(params.Limit).
+		Offset((params.Page - 1) * params.Limit).
+		Find(&fields).Error
+	if err != nil {
+		return nil, 0, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	return fields, total, nil
+}
+
+func (f *FieldRepository) FindTrashedByUUID(ctx context.Context, uuid string) (*models.Field, error) {
+	var field models.Field
+
+	err := f.db.WithContext(ctx).Unscoped().
+		Where("uuid = ? AND deleted_at IS NOT NULL", uuid).First(&field).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorWrap.WrapErrorContext(ctx, errField.ErrFieldNotFound)
+		}
+
+		return nil, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	return &field, nil
+}
+
+// Restore brings the field back with the schedules that were trashed along with
+// it. Schedules whose time is still in the trash stay there. The slot index
+// rejects the restore when another live schedule took one of their slots.
+func (f *FieldRepository) Restore(ctx context.Context, uuid string) error {
+	err := f.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var field models.Field
+		err := tx.Unscoped().Where("uuid = ? AND deleted_at IS NOT NULL", uuid).First(&field).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Unscoped().
+			Model(&models.FieldSchedule{}).
+			Where("field_id = ? AND deleted_at = ?", field.ID, field.DeletedAt.Time).
+			Where("NOT EXISTS (SELECT 1 FROM times WHERE times.id = field_schedules.time_id AND times.deleted_at IS NOT NULL)").
+			UpdateColumn("deleted_at", nil).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Unscoped().Model(&field).UpdateColumn("deleted_at", nil).Error
+	})
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errorWrap.WrapError(errField.ErrFieldNotFound)
+			return errorWrap.WrapErrorContext(ctx, errField.ErrFieldNotFound)
+		}
+		if errorWrap.IsUniqueViolation(err, scheduleSlotIndex) {
+			return errorWrap.WrapErrorContext(ctx, errFieldSchedule.ErrFieldShceduleExist)
 		}
 
-		return errorWrap.WrapError(errConstants.ErrSqlQuery)
+		return errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
 	}
 
 	return nil
 }
+
+// Purge permanently removes fields trashed before the given time. Their
+// schedules went to the trash with them and are purged first; a field that a
+// schedule still points to is kept, since removing it would cascade to it.
+func (f *FieldRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	result := f.db.WithContext(ctx).Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Where("NOT EXISTS (SELECT 1 FROM field_schedules WHERE field_schedules.field_id = fields.id)").
+		Delete(&models.Field{})
+	if result.Error != nil {
+		return 0, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	return result.RowsAffected, nil
+}
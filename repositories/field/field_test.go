@@ -0,0 +1,55 @@
+package repositories
+
+import "testing"
+
+func TestPrefixQuery(t *testing.T) {
+	tests := []struct {
+		name string
+		term string
+		want string
+	}{
+		{name: "single word", term: "futsal", want: "futsal:*"},
+		{name: "several words", term: "Futsal Arena", want: "futsal:* | arena:*"},
+		{name: "letters and digits", term: "court 12", want: "court:* | 12:*"},
+		{name: "tsquery operators are dropped", term: "a & b | !c:* <-> (d)", want: ""},
+		{name: "operators between words", term: "futsal&arena|!mini", want: "futsal:* | arena:* | mini:*"},
+		{name: "single letters are dropped", term: "o'neil s", want: "neil:*"},
+		{name: "non ascii letters", term: "lapangan Bandung-Jaya", want: "lapangan:* | bandung:* | jaya:*"},
+		{name: "blank", term: "   ", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := prefixQuery(tt.term)
+			if got != tt.want {
+				t.Errorf("prefixQuery(%q) = %q, want %q", tt.term, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkSnippet(t *testing.T) {
+	tests := []struct {
+		name    string
+		snippet string
+		want    string
+	}{
+		{name: "plain text", snippet: "Futsal Arena", want: "Futsal Arena"},
+		{name: "match", snippet: snippetStart + "Futsal" + snippetStop + " Arena", want: "<mark>Futsal</mark> Arena"},
+		{
+			name:    "stored markup is escaped",
+			snippet: snippetStart + "Futsal" + snippetStop + ` <script>alert("x")</script>`,
+			want:    `<mark>Futsal</mark> &lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt;`,
+		},
+		{name: "ampersand", snippet: "Tom & Jerry", want: "Tom &amp; Jerry"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := markSnippet(tt.snippet)
+			if got != tt.want {
+				t.Errorf("markSnippet(%q) = %q, want %q", tt.snippet, got, tt.want)
+			}
+		})
+	}
+}
@@ -3,78 +3,233 @@ package repositories
 import (
 	"context"
 	"errors"
+	"field-service/common/cursor"
 	errorWrap "field-service/common/error"
+	"field-service/common/query"
 	"field-service/constants"
 	errConstants "field-service/constants/error"
 	errField "field-service/constants/error/field"
+	errFieldSchedule "field-service/constants/error/field_schedule"
 	"field-service/domain/dto"
 	"field-service/domain/models"
-	"fmt"
+	outboxRepo "field-service/repositories/outbox"
+	"strconv"
+	"time"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/plugin/dbresolver"
 )
 
+// slotIndex keeps a field from having two live schedules for the same date and time.
+const slotIndex = "idx_field_schedules_slot"
+
 type FieldScheduleRepository struct {
 	db *gorm.DB
 }
 
 type IFieldScheduleRepository interface {
 	FindAllWithPagination(context.Context, *dto.FieldScheduleRequestParam) ([]models.FieldSchedule, int64, error)
+	FindAllWithCursor(context.Context, *dto.FieldScheduleRequestParam) ([]models.FieldSchedule, string, error)
+	Count(context.Context, *dto.FieldScheduleRequestParam) (int64, error)
 	FindAllByFieldIDAndDate(context.Context, int, string) ([]models.FieldSchedule, error)
 	FindByUUID(context.Context, string) (*models.FieldSchedule, error)
 	FindByDateAndTimeID(context.Context, string, int, int) (*models.FieldSchedule, error)
+	CountAvailableByField(context.Context) ([]dto.AvailableSlotCount, error)
 	Create(context.Context, []models.FieldSchedule) error
 	Update(context.Context, string, *models.FieldSchedule) (*models.FieldSchedule, error)
-	UpdateStatus(context.Context, constants.FieldScheduleStatus, string) error
+	UpdateStatus(context.Context, constants.FieldScheduleStatus, *uuid.UUID, string) error
 	Delete(context.Context, string) error
+	FindAllTrashed(context.Context, *dto.TrashRequestParam) ([]models.FieldSchedule, int64, error)
+	FindTrashedByUUID(context.Context, string) (*models.FieldSchedule, error)
+	Restore(context.Context, string) error
+	Purge(context.Context, time.Time) (int64, error)
 }
 
 func NewFieldScheduleRepository(db *gorm.DB) IFieldScheduleRepository {
 	return &FieldScheduleRepository{db: db}
 }
 
+// unscoped lets a schedule keep showing the field and time it was created for
+// after either of them has been moved to the trash.
+func unscoped(db *gorm.DB) *gorm.DB {
+	return db.Unscoped()
+}
+
+// sortSpec is every order the schedule listings accept. Field and time columns
+// come from the joins in filter.
+var sortSpec = query.Spec[models.FieldSchedule]{
+	Sorts: map[string]query.Sort[models.FieldSchedule]{
+		"date": {Column: "field_schedules.date", Value: func(schedule models.FieldSchedule) string {
+			return schedule.Date.Format(time.DateOnly)
+		}},
+		"start_time": {Column: "times.start_time", Value: func(schedule models.FieldSchedule) string {
+			return schedule.Time.StartTime
+		}},
+		"status": {Column: "field_schedules.status", Value: func(schedule models.FieldSchedule) string {
+			return strconv.Itoa(int(schedule.Status))
+		}},
+		"field_name": {Column: "fields.name", Value: func(schedule models.FieldSchedule) string {
+			return schedule.Field.Name
+		}},
+		"price_per_hour": {Column: "fields.price_per_hour", Value: func(schedule models.FieldSchedule) string {
+			return strconv.Itoa(schedule.Field.PricePerHour)
+		}},
+		"created_at": {Column: "field_schedules.created_at", Value: func(schedule models.FieldSchedule) string {
+			return cursor.FormatTime(schedule.CreatedAt)
+		}},
+		"updated_at": {Column: "field_schedules.updated_at", Value: func(schedule models.FieldSchedule) string {
+			return cursor.FormatTime(schedule.UpdatedAt)
+		}},
+	},
+	Default: []string{"created_at"},
+	ID: query.Sort[models.FieldSchedule]{Column: "field_schedules.id", Value: func(schedule models.FieldSchedule) string {
+		return strconv.FormatUint(uint64(schedule.ID), 10)
+	}},
+}
+
+// cursorScope ties schedule cursors to this listing.
+const cursorScope = "field_schedules"
+
+// filter joins the field and time of each schedule, trashed or not, so they can
+// be filtered and sorted on.
+func (f *FieldScheduleRepository) filter(db *gorm.DB, params *dto.FieldScheduleRequestParam) *gorm.DB {
+	db = db.
+		Joins("JOIN fields ON fields.id = field_schedules.field_id").
+		Joins("JOIN times ON times.id = field_schedules.time_id")
+
+	if params.FieldUUID != nil {
+		db = db.Where("fields.uuid = ?", *params.FieldUUID)
+	}
+
+	if params.StartDate != nil {
+		db = db.Where("field_schedules.date >= ?", *params.StartDate)
+	}
+
+	if params.EndDate != nil {
+		db = db.Where("field_schedules.date <= ?", *params.EndDate)
+	}
+
+	if params.Status != nil {
+		db = db.Where("field_schedules.status = ?", params.Status.GetStatusInt())
+	}
+
+	if params.StartTime != nil {
+		db = db.Where("times.start_time >= ?", *params.StartTime)
+	}
+
+	if params.EndTime != nil {
+		db = db.Where("times.end_time <= ?", *params.EndTime)
+	}
+
+	if params.MinPrice != nil {
+		db = db.Where("fields.price_per_hour >= ?", *params.MinPrice)
+	}
+
+	if params.MaxPrice != nil {
+		db = db.Where("fields.price_per_hour <= ?", *params.MaxPrice)
+	}
+
+	if params.Name != nil && *params.Name != "" {
+		db = db.Where("fields.name ILIKE ?", query.Contains(*params.Name))
+	}
+
+	return db
+}
+
 func (f *FieldScheduleRepository) FindAllWithPagination(ctx context.Context, params *dto.FieldScheduleRequestParam) ([]models.FieldSchedule, int64, error) {
 	var (
 		fields []models.FieldSchedule
-		sort   string
 		total  int64
 	)
 
-	if params.SortColumn != nil {
-		sort = fmt.Sprintf("%s %s", *params.SortColumn, *params.SortOrder)
-	} else {
-		sort = "created_at desc"
+	keys, err := sortSpec.Keys(params.SortColumn, params.SortOrder)
+	if err != nil {
+		return nil, 0, err
 	}
 
 	limit := params.Limit
 	offset := (params.Page - 1) * params.Limit
-	err := f.db.WithContext(ctx).
+	err = f.filter(f.db.WithContext(ctx).Clauses(dbresolver.Use(constants.ReadReplica)), params).
+		Preload("Field", unscoped).
+		Preload("Time", unscoped).
 		Limit(limit).
 		Offset(offset).
-		Order(sort).
+		Order(cursor.Order(keys)).
 		Find(&fields).Error
 
 	if err != nil {
-		return nil, 0, errorWrap.WrapError(errConstants.ErrSqlQuery)
+		return nil, 0, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
 	}
 
-	err = f.db.WithContext(ctx).
-		Model(&fields).Count(&total).Error
-
+	total, err = f.Count(ctx, params)
 	if err != nil {
-		return nil, 0, errorWrap.WrapError(errConstants.ErrSqlQuery)
+		return nil, 0, err
 	}
 
 	return fields, total, nil
 }
 
+func (f *FieldScheduleRepository) FindAllWithCursor(ctx context.Context, params *dto.FieldScheduleRequestParam) ([]models.FieldSchedule, string, error) {
+	var fieldSchedules []models.FieldSchedule
+
+	keys, err := sortSpec.Keys(params.SortColumn, params.SortOrder)
+	if err != nil {
+		return nil, "", err
+	}
+
+	db := f.filter(f.db.WithContext(ctx).Clauses(dbresolver.Use(constants.ReadReplica)), params).
+		Preload("Field", unscoped).
+		Preload("Time", unscoped)
+	if params.Cursor != nil {
+		values, err := cursor.Decode(cursorScope, keys, *params.Cursor)
+		if err != nil {
+			return nil, "", err
+		}
+
+		condition, args := cursor.After(keys, values)
+		db = db.Where(condition, args...)
+	}
+
+	// One extra row tells whether another page follows.
+	err = db.
+		Order(cursor.Order(keys)).
+		Limit(params.Limit + 1).
+		Find(&fieldSchedules).Error
+	if err != nil {
+		return nil, "", errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	if len(fieldSchedules) <= params.Limit {
+		return fieldSchedules, "", nil
+	}
+
+	fieldSchedules = fieldSchedules[:params.Limit]
+	next := cursor.Encode(cursorScope, keys, sortSpec.Values(keys, fieldSchedules[len(fieldSchedules)-1]))
+
+	return fieldSchedules, next, nil
+}
+
+func (f *FieldScheduleRepository) Count(ctx context.Context, params *dto.FieldScheduleRequestParam) (int64, error) {
+	var total int64
+	err := f.filter(f.db.WithContext(ctx).Clauses(dbresolver.Use(constants.ReadReplica)).Model(&models.FieldSchedule{}), params).
+		Count(&total).Error
+	if err != nil {
+		return 0, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	return total, nil
+}
+
 func (f *FieldScheduleRepository) FindAllByFieldIDAndDate(ctx context.Context, fieldID int, date string) ([]models.FieldSchedule, error) {
 	var fields []models.FieldSchedule
 
 	err := f.db.
 		WithContext(ctx).
-		Preload("Field").
-		Preload("Time").
+		Clauses(dbresolver.Use(constants.ReadReplica)).
+		Preload("Field", unscoped).
+		Preload("Time", unscoped).
 		Where("field_id = ?", fieldID).
 		Where("date = ?", date).
 		Joins("LEFT JOIN times ON times.id = field_schedules.time_id").
@@ -83,7 +238,7 @@ func (f *FieldScheduleRepository) FindAllByFieldIDAndDate(ctx context.Context, f
 		Error
 
 	if err != nil {
-		return nil, errorWrap.WrapError(errConstants.ErrSqlQuery)
+		return nil, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
 	}
 
 	return fields, nil
@@ -92,16 +247,16 @@ func (f *FieldScheduleRepository) FindAllByFieldIDAndDate(ctx context.Context, f
 func (f *FieldScheduleRepository) FindByUUID(ctx context.Context, uuid string) (*models.FieldSchedule, error) {
 	var field models.FieldSchedule
 	err := f.db.WithContext(ctx).
-		Preload("Field").
-		Preload("Time").
+		Preload("Field", unscoped).
+		Preload("Time", unscoped).
 		Where("uuid = ?", uuid).
 		First(&field).
 		Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errorWrap.WrapError(errField.ErrFieldNotFound)
+			return nil, errorWrap.WrapErrorContext(ctx, errField.ErrFieldNotFound)
 		}
-		return nil, errorWrap.WrapError(errConstants.ErrSqlQuery)
+		return nil, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
 	}
 	return &field, nil
 }
@@ -119,17 +274,63 @@ func (f *FieldScheduleRepository) FindByDateAndTimeID(ctx context.Context, date
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
 		}
-		return nil, errorWrap.WrapError(errConstants.ErrSqlQuery)
+		return nil, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
 	}
 
 	return &field, nil
 
 }
 
+func (f *FieldScheduleRepository) CountAvailableByField(ctx context.Context) ([]dto.AvailableSlotCount, error) {
+	var counts []dto.AvailableSlotCount
+	err := f.db.WithContext(ctx).
+		Clauses(dbresolver.Use(constants.ReadReplica)).
+		Model(&models.FieldSchedule{}).
+		Select("fields.uuid AS field_uuid, fields.name AS field_name, COUNT(*) AS total").
+		Joins("JOIN fields ON fields.id = field_schedules.field_id AND fields.deleted_at IS NULL").
+		Where("field_schedules.status = ? AND field_schedules.date >= CURRENT_DATE", constants.Available).
+		Group("fields.uuid, fields.name").
+		Scan(&counts).Error
+	if err != nil {
+		return nil, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	return counts, nil
+}
+
+func (f *FieldScheduleRepository) toEvent(eventType constants.EventType, fieldSchedule *models.FieldSchedule) (models.OutboxEvent, error) {
+	return outboxRepo.NewEvent(eventType, constants.AggregateFieldSchedule, fieldSchedule.UUID, dto.FieldScheduleEvent{
+		UUID:    fieldSchedule.UUID,
+		FieldID: fieldSchedule.FieldID,
+		TimeID:  fieldSchedule.TimeID,
+		Date:    fieldSchedule.Date.Format(time.DateOnly),
+		Status:  fieldSchedule.Status.GetStatusString(),
+	})
+}
+
 func (f *FieldScheduleRepository) Create(ctx context.Context, request []models.FieldSchedule) error {
-	err := f.db.WithContext(ctx).Create(&request).Error
+	err := f.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Create(&request).Error
+		if err != nil {
+			return err
+		}
+
+		events := make([]models.OutboxEvent, 0, len(request))
+		for i := range request {
+			event, err := f.toEvent(constants.EventFieldScheduleCreated, &request[i])
+			if err != nil {
+				return err
+			}
+			events = append(events, event)
+		}
+
+		return outboxRepo.NewOutboxRepository(tx).Create(ctx, events...)
+	})
 	if err != nil {
-		return errorWrap.WrapError(errConstants.ErrSqlQuery)
+		if errorWrap.IsUniqueViolation(err, slotIndex) {
+			return errorWrap.WrapErrorContext(ctx, errFieldSchedule.ErrFieldShceduleExist)
+		}
+		return errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
 	}
 
 	return nil
@@ -142,24 +343,47 @@ func (f *FieldScheduleRepository) Update(ctx context.Context, uuid string, reque
 	}
 
 	fieldSchedule.Date = request.Date
-	err = f.db.WithContext(ctx).Save(&fieldSchedule).Error
+	fieldSchedule.TimeID = request.TimeID
+	// The preloaded Time would otherwise write its old ID back into time_id.
+	err = f.db.WithContext(ctx).Omit(clause.Associations).Save(&fieldSchedule).Error
 	if err != nil {
-		return nil, errorWrap.WrapError(errConstants.ErrSqlQuery)
+		if errorWrap.IsUniqueViolation(err, slotIndex) {
+			return nil, errorWrap.WrapErrorContext(ctx, errFieldSchedule.ErrFieldShceduleExist)
+		}
+		return nil, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
 	}
 
-	return fieldSchedule, nil
+	return f.FindByUUID(ctx, uuid)
 }
 
-func (f *FieldScheduleRepository) UpdateStatus(ctx context.Context, status constants.FieldScheduleStatus, uuid string) error {
+// UpdateStatus sets the status and the order holding the slot; orderUUID is
+// cleared when the slot becomes available again.
+func (f *FieldScheduleRepository) UpdateStatus(ctx context.Context, status constants.FieldScheduleStatus, orderUUID *uuid.UUID, uuid string) error {
 	fieldSchedule, err := f.FindByUUID(ctx, uuid)
 	if err != nil {
 		return err
 	}
 
 	fieldSchedule.Status = status
-	err = f.db.WithContext(ctx).Save(&fieldSchedule).Error
+	fieldSchedule.OrderUUID = orderUUID
+	if status == constants.Available {
+		fieldSchedule.OrderUUID = nil
+	}
+	err = f.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Save(&fieldSchedule).Error
+		if err != nil {
+			return err
+		}
+
+		event, err := f.toEvent(constants.EventFieldScheduleStatusChanged, fieldSchedule)
+		if err != nil {
+			return err
+		}
+
+		return outboxRepo.NewOutboxRepository(tx).Create(ctx, event)
+	})
 	if err != nil {
-		return errorWrap.WrapError(errConstants.ErrSqlQuery)
+		return errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
 	}
 
 	return nil
@@ -168,7 +392,86 @@ func (f *FieldScheduleRepository) UpdateStatus(ctx context.Context, status const
 func (f *FieldScheduleRepository) Delete(ctx context.Context, uuid string) error {
 	err := f.db.WithContext(ctx).Where("uuid = ?", uuid).Delete(&models.FieldSchedule{}).Error
 	if err != nil {
-		return errorWrap.WrapError(errConstants.ErrSqlQuery)
+		return errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
 	}
 	return nil
 }
+
+func (f *FieldScheduleRepository) FindAllTrashed(ctx context.Context, params *dto.TrashRequestParam) ([]models.FieldSchedule, int64, error) {
+	var (
+		fieldSchedules []models.FieldSchedule
+		total          int64
+	)
+
+	query := f.db.WithContext(ctx).Unscoped().Model(&models.FieldSchedule{}).Where("deleted_at IS NOT NULL").
+		Session(&gorm.Session{})
+	err := query.Count(&total).Error
+	if err != nil {
+		return nil, 0, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	err = query.
+		Preload("Field", unscoped).
+		Preload("Time", unscoped).
+		Order("deleted_at desc").
+		Limit(params.Limit).
+		Offset((params.Page - 1) * params.Limit).
+		Find(&fieldSchedules).Error
+	if err != nil {
+		return nil, 0, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	return fieldSchedules, total, nil
+}
+
+func (f *FieldScheduleRepository) FindTrashedByUUID(ctx context.Context, uuid string) (*models.FieldSchedule, error) {
+	var fieldSchedule models.FieldSchedule
+
+	err := f.db.WithContext(ctx).Unscoped().
+		Preload("Field", unscoped).
+		Preload("Time", unscoped).
+		Where("uuid = ? AND deleted_at IS NOT NULL", uuid).
+		First(&fieldSchedule).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorWrap.WrapErrorContext(ctx, errFieldSchedule.ErrFieldScheduleNotFound)
+		}
+
+		return nil, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	return &fieldSchedule, nil
+}
+
+// Restore brings a trashed schedule back. The slot index rejects it when another
+// live schedule took the same field, date and time in the meantime.
+func (f *FieldScheduleRepository) Restore(ctx context.Context, uuid string) error {
+	result := f.db.WithContext(ctx).Unscoped().
+		Model(&models.FieldSchedule{}).
+		Where("uuid = ? AND deleted_at IS NOT NULL", uuid).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		if errorWrap.IsUniqueViolation(result.Error, slotIndex) {
+			return errorWrap.WrapErrorContext(ctx, errFieldSchedule.ErrFieldShceduleExist)
+		}
+		return errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	if result.RowsAffected == 0 {
+		return errorWrap.WrapErrorContext(ctx, errFieldSchedule.ErrFieldScheduleNotFound)
+	}
+
+	return nil
+}
+
+// Purge permanently removes schedules trashed before the given time.
+func (f *FieldScheduleRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	result := f.db.WithContext(ctx).Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Delete(&models.FieldSchedule{})
+	if result.Error != nil {
+		return 0, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,114 @@
+package repositories
+
+import (
+	"context"
+	errorWrap "field-service/common/error"
+	"field-service/constants"
+	errConstants "field-service/constants/error"
+	"field-service/domain/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IdempotencyRepository struct {
+	db *gorm.DB
+}
+
+type IIdempotencyRepository interface {
+	Reserve(context.Context, *models.IdempotencyKey) (*models.IdempotencyKey, bool, error)
+	Complete(context.Context, *models.IdempotencyKey) error
+	Release(context.Context, *models.IdempotencyKey) error
+	DeleteExpired(context.Context) (int64, error)
+}
+
+func NewIdempotencyRepository(db *gorm.DB) IIdempotencyRepository {
+	return &IdempotencyRepository{db: db}
+}
+
+// Reserve inserts a processing row for the key and caller. When a live row
+// already exists it is returned instead and reserved is false. A processing row
+// whose lock has run out was left by an instance that died mid-request and is
+// taken over.
+func (i *IdempotencyRepository) Reserve(ctx context.Context, request *models.IdempotencyKey) (*models.IdempotencyKey, bool, error) {
+	var (
+		existing models.IdempotencyKey
+		reserved bool
+	)
+
+	err := i.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		err := tx.
+			Where("key = ? AND caller = ?", request.Key, request.Caller).
+			Where("expires_at < ? OR (status = ? AND locked_until < ?)", now, constants.IdempotencyProcessing, now).
+			Delete(&models.IdempotencyKey{}).Error
+		if err != nil {
+			return err
+		}
+
+		result := tx.
+			Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "key"}, {Name: "caller"}},
+				DoNothing: true,
+			}).
+			Create(request)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected > 0 {
+			reserved = true
+			return nil
+		}
+
+		return tx.Where("key = ? AND caller = ?", request.Key, request.Caller).First(&existing).Error
+	})
+	if err != nil {
+		return nil, false, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	if reserved {
+		return request, true, nil
+	}
+
+	return &existing, false, nil
+}
+
+func (i *IdempotencyRepository) Complete(ctx context.Context, request *models.IdempotencyKey) error {
+	err := i.db.WithContext(ctx).
+		Model(&models.IdempotencyKey{}).
+		Where("id = ?", request.ID).
+		Updates(map[string]any{
+			"status":        constants.IdempotencyCompleted,
+			"status_code":   request.StatusCode,
+			"content_type":  request.ContentType,
+			"response_body": request.ResponseBody,
+			"locked_until":  nil,
+			"updated_at":    time.Now(),
+		}).Error
+	if err != nil {
+		return errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	return nil
+}
+
+// Release drops a reservation so the same key can be retried.
+func (i *IdempotencyRepository) Release(ctx context.Context, request *models.IdempotencyKey) error {
+	err := i.db.WithContext(ctx).Where("id = ?", request.ID).Delete(&models.IdempotencyKey{}).Error
+	if err != nil {
+		return errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	return nil
+}
+
+func (i *IdempotencyRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	result := i.db.WithContext(ctx).Where("expires_at < ?", time.Now()).Delete(&models.IdempotencyKey{})
+	if result.Error != nil {
+		return 0, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,171 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	errorWrap "field-service/common/error"
+	"field-service/constants"
+	errConstants "field-service/constants/error"
+	"field-service/domain/dto"
+	"field-service/domain/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OutboxRepository struct {
+	db *gorm.DB
+}
+
+type IOutboxRepository interface {
+	Create(context.Context, ...models.OutboxEvent) error
+	ProcessPending(context.Context, int, int, time.Duration, func(*models.OutboxEvent) error) (int, error)
+}
+
+func NewOutboxRepository(db *gorm.DB) IOutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+// NewEvent builds an outbox row; pass the transaction's db to Create so the
+// event commits or rolls back together with the business change.
+func NewEvent(eventType constants.EventType, aggregateType string, aggregateUUID uuid.UUID, data any) (models.OutboxEvent, error) {
+	event := dto.DomainEvent{
+		ID:            uuid.New(),
+		Type:          eventType,
+		AggregateType: aggregateType,
+		AggregateUUID: aggregateUUID,
+		OccurredAt:    time.Now(),
+		Data:          data,
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return models.OutboxEvent{}, err
+	}
+
+	return models.OutboxEvent{
+		UUID:          event.ID,
+		AggregateType: aggregateType,
+		AggregateUUID: aggregateUUID,
+		EventType:     eventType,
+		Payload:       models.JSON(payload),
+		Status:        constants.OutboxPending,
+	}, nil
+}
+
+func (o *OutboxRepository) Create(ctx context.Context, events ...models.OutboxEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	err := o.db.WithContext(ctx).Create(&events).Error
+	if err != nil {
+		return errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	return nil
+}
+
+// ProcessPending claims a batch of pending events in creation order and hands
+// each one to publish. The claim is a lease committed before anything is
+// published, so no row lock is held across the network and concurrent relays
+// skip the batch until the lease runs out. Events that keep failing are parked
+// as failed after maxAttempts.
+func (o *OutboxRepository) ProcessPending(ctx context.Context, limit int, maxAttempts int, lease time.Duration, publish func(*models.OutboxEvent) error) (int, error) {
+	events, err := o.claimPending(ctx, limit, lease)
+	if err != nil {
+		return 0, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	var processed int
+	for i := range events {
+		event := &events[i]
+		event.Attempts++
+		event.LockedUntil = nil
+		err = publish(event)
+		if err != nil {
+			event.LastError = err.Error()
+			if event.Attempts >= maxAttempts {
+				event.Status = constants.OutboxFailed
+			}
+		} else {
+			now := time.Now()
+			event.Status = constants.OutboxPublished
+			event.PublishedAt = &now
+			event.LastError = ""
+		}
+
+		err = o.db.WithContext(ctx).Save(event).Error
+		if err != nil {
+			return processed, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+		}
+
+		if event.Status == constants.OutboxPublished {
+			processed++
+		}
+
+		// Keep ordering: stop at the first retryable failure, hand the rest of
+		// the batch back and try again later.
+		if event.Status == constants.OutboxPending {
+			err = o.release(ctx, events[i+1:])
+			if err != nil {
+				return processed, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+			}
+			break
+		}
+	}
+
+	return processed, nil
+}
+
+func (o *OutboxRepository) claimPending(ctx context.Context, limit int, lease time.Duration) ([]models.OutboxEvent, error) {
+	var events []models.OutboxEvent
+	err := o.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ?", constants.OutboxPending).
+			Where("locked_until IS NULL OR locked_until <= ?", now).
+			Order("id asc").
+			Limit(limit).
+			Find(&events).Error
+		if err != nil {
+			return err
+		}
+
+		if len(events) == 0 {
+			return nil
+		}
+
+		lockedUntil := now.Add(lease)
+		ids := make([]uint, 0, len(events))
+		for i := range events {
+			events[i].LockedUntil = &lockedUntil
+			ids = append(ids, events[i].ID)
+		}
+
+		return tx.Model(&models.OutboxEvent{}).
+			Where("id IN ?", ids).
+			Update("locked_until", lockedUntil).Error
+	})
+
+	return events, err
+}
+
+func (o *OutboxRepository) release(ctx context.Context, events []models.OutboxEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	ids := make([]uint, 0, len(events))
+	for _, event := range events {
+		ids = append(ids, event.ID)
+	}
+
+	return o.db.WithContext(ctx).
+		Model(&models.OutboxEvent{}).
+		Where("id IN ?", ids).
+		Update("locked_until", nil).Error
+}
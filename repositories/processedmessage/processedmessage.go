@@ -0,0 +1,41 @@
+package repositories
+
+import (
+	"context"
+	errorWrap "field-service/common/error"
+	errConstants "field-service/constants/error"
+	errMessage "field-service/constants/error/message"
+	"field-service/domain/models"
+
+	"gorm.io/gorm"
+)
+
+// messageIDIndex makes a second consumer of the same message fail to record it.
+const messageIDIndex = "idx_processed_messages_message_id"
+
+type ProcessedMessageRepository struct {
+	db *gorm.DB
+}
+
+type IProcessedMessageRepository interface {
+	Create(context.Context, *models.ProcessedMessage) error
+}
+
+func NewProcessedMessageRepository(db *gorm.DB) IProcessedMessageRepository {
+	return &ProcessedMessageRepository{db: db}
+}
+
+// Create claims a message. Run it in the transaction that applies the message:
+// a concurrent redelivery waits on the unique index and then gets
+// ErrMessageProcessed, and a crash before commit leaves the message unclaimed.
+func (p *ProcessedMessageRepository) Create(ctx context.Context, message *models.ProcessedMessage) error {
+	err := p.db.WithContext(ctx).Create(message).Error
+	if err != nil {
+		if errorWrap.IsUniqueViolation(err, messageIDIndex) {
+			return errMessage.ErrMessageProcessed
+		}
+		return errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	return nil
+}
@@ -1,11 +1,20 @@
 package repositories
 
 import (
+	"context"
+
 	"gorm.io/gorm"
 
+	auditLogRepo "field-service/repositories/auditlog"
+	deadLetterMessageRepo "field-service/repositories/deadlettermessage"
 	fieldRepo "field-service/repositories/field"
 	fieldScheduleRepo "field-service/repositories/fieldschedule"
+	idempotencyRepo "field-service/repositories/idempotency"
+	outboxRepo "field-service/repositories/outbox"
+	processedMessageRepo "field-service/repositories/processedmessage"
+	replayKeyRepo "field-service/repositories/replaykey"
 	timeRepo "field-service/repositories/time"
+	webhookRepo "field-service/repositories/webhook"
 )
 
 type Registry struct {
@@ -16,6 +25,14 @@ type IRepostitoryRegistry interface {
 	GetFieldRepository() fieldRepo.IFieldRepository
 	GetFieldScheduleRepository() fieldScheduleRepo.IFieldScheduleRepository
 	GetTimeRepository() timeRepo.ITimeRepository
+	GetAuditLogRepository() auditLogRepo.IAuditLogRepository
+	GetWebhookRepository() webhookRepo.IWebhookRepository
+	GetOutboxRepository() outboxRepo.IOutboxRepository
+	GetProcessedMessageRepository() processedMessageRepo.IProcessedMessageRepository
+	GetIdempotencyRepository() idempotencyRepo.IIdempotencyRepository
+	GetDeadLetterMessageRepository() deadLetterMessageRepo.IDeadLetterMessageRepository
+	GetReplayKeyRepository() replayKeyRepo.IReplayKeyRepository
+	Transaction(context.Context, func(IRepostitoryRegistry) error) error
 }
 
 func NewRepositoryRegistry(db *gorm.DB) IRepostitoryRegistry {
@@ -35,3 +52,39 @@ func (r *Registry) GetFieldScheduleRepository() fieldScheduleRepo.IFieldSchedule
 func (r *Registry) GetTimeRepository() timeRepo.ITimeRepository {
 	return timeRepo.NewTimeRepository(r.db)
 }
+
+func (r *Registry) GetAuditLogRepository() auditLogRepo.IAuditLogRepository {
+	return auditLogRepo.NewAuditLogRepository(r.db)
+}
+
+func (r *Registry) GetWebhookRepository() webhookRepo.IWebhookRepository {
+	return webhookRepo.NewWebhookRepository(r.db)
+}
+
+func (r *Registry) GetOutboxRepository() outboxRepo.IOutboxRepository {
+	return outboxRepo.NewOutboxRepository(r.db)
+}
+
+func (r *Registry) GetProcessedMessageRepository() processedMessageRepo.IProcessedMessageRepository {
+	return processedMessageRepo.NewProcessedMessageRepository(r.db)
+}
+
+func (r *Registry) GetIdempotencyRepository() idempotencyRepo.IIdempotencyRepository {
+	return idempotencyRepo.NewIdempotencyRepository(r.db)
+}
+
+func (r *Registry) GetDeadLetterMessageRepository() deadLetterMessageRepo.IDeadLetterMessageRepository {
+	return deadLetterMessageRepo.NewDeadLetterMessageRepository(r.db)
+}
+
+func (r *Registry) GetReplayKeyRepository() replayKeyRepo.IReplayKeyRepository {
+	return replayKeyRepo.NewReplayKeyRepository(r.db)
+}
+
+// Transaction runs fn with repositories that share one database transaction.
+// It commits when fn returns nil and rolls back otherwise.
+func (r *Registry) Transaction(ctx context.Context, fn func(IRepostitoryRegistry) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(NewRepositoryRegistry(tx))
+	})
+}
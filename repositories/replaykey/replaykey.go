@@ -0,0 +1,61 @@
+package repositories
+
+import (
+	"context"
+	errorWrap "field-service/common/error"
+	errConstants "field-service/constants/error"
+	"field-service/domain/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReplayKeyRepository struct {
+	db *gorm.DB
+}
+
+type IReplayKeyRepository interface {
+	Remember(context.Context, string, time.Time) (bool, error)
+	DeleteExpired(context.Context) (int64, error)
+}
+
+func NewReplayKeyRepository(db *gorm.DB) IReplayKeyRepository {
+	return &ReplayKeyRepository{db: db}
+}
+
+// Remember stores the key and reports false when a live row already holds it.
+// An expired row is taken over, so cleanup may lag behind without harm.
+func (r *ReplayKeyRepository) Remember(ctx context.Context, key string, expiresAt time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "key"}},
+			DoUpdates: clause.Assignments(map[string]any{
+				"expires_at": gorm.Expr("EXCLUDED.expires_at"),
+				"created_at": gorm.Expr("EXCLUDED.created_at"),
+			}),
+			Where: clause.Where{Exprs: []clause.Expression{
+				gorm.Expr("replay_keys.expires_at <= ?", time.Now()),
+			}},
+		}).
+		Create(&models.ReplayKey{
+			Key:       key,
+			ExpiresAt: expiresAt,
+		})
+	if result.Error != nil {
+		return false, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (r *ReplayKeyRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("expires_at < ?", time.Now()).
+		Delete(&models.ReplayKey{})
+	if result.Error != nil {
+		return 0, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	return result.RowsAffected, nil
+}
@@ -5,13 +5,20 @@ import (
 	"errors"
 	errorWrap "field-service/common/error"
 	errConstants "field-service/constants/error"
+	errFieldSchedule "field-service/constants/error/field_schedule"
 	errTime "field-service/constants/error/time"
+	"field-service/domain/dto"
 	"field-service/domain/models"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+// scheduleSlotIndex keeps a field from having two live schedules for the same
+// date and time.
+const scheduleSlotIndex = "idx_field_schedules_slot"
+
 type TimeRepository struct {
 	db *gorm.DB
 }
@@ -20,7 +27,13 @@ type ITimeRepository interface {
 	FindAll(context.Context) ([]models.Time, error)
 	FindByUUID(context.Context, string) (*models.Time, error)
 	FindByID(context.Context, int) (*models.Time, error)
+	FindByStartTimeAndEndTime(context.Context, string, string) (*models.Time, error)
 	Create(context.Context, *models.Time) (*models.Time, error)
+	Delete(context.Context, string) error
+	FindAllTrashed(context.Context, *dto.TrashRequestParam) ([]models.Time, int64, error)
+	FindTrashedByUUID(context.Context, string) (*models.Time, error)
+	Restore(context.Context, string) error
+	Purge(context.Context, time.Time) (int64, error)
 }
 
 func NewTimeRepository(db *gorm.DB) ITimeRepository {
@@ -31,7 +44,7 @@ func (t *TimeRepository) FindAll(ctx context.Context) ([]models.Time, error) {
 	var times []models.Time
 	err := t.db.WithContext(ctx).Find(&times).Error
 	if err != nil {
-		return nil, errorWrap.WrapError(errConstants.ErrSqlQuery)
+		return nil, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
 	}
 
 	return times, nil
@@ -42,10 +55,10 @@ func (t *TimeRepository) FindByUUID(ctx context.Context, uuid string) (*models.T
 	err := t.db.WithContext(ctx).Where("uuid = ?", uuid).First(&time).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errorWrap.WrapError(errTime.ErrTimeNotFound)
+			return nil, errorWrap.WrapErrorContext(ctx, errTime.ErrTimeNotFound)
 		}
 
-		return nil, errorWrap.WrapError(errConstants.ErrSqlQuery)
+		return nil, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
 	}
 
 	return &time, nil
@@ -57,10 +70,10 @@ func (t *TimeRepository) FindByID(ctx context.Context, id int) (*models.Time, er
 	err := t.db.WithContext(ctx).Where("id = ?", id).First(&time).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errorWrap.WrapError(errTime.ErrTimeNotFound)
+			return nil, errorWrap.WrapErrorContext(ctx, errTime.ErrTimeNotFound)
 		}
 
-		return nil, errorWrap.WrapError(errConstants.ErrSqlQuery)
+		return nil, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
 	}
 
 	return &time, nil
@@ -70,7 +83,148 @@ func (t *TimeRepository) Create(ctx context.Context, time *models.Time) (*models
 	time.UUID = uuid.New()
 	err := t.db.WithContext(ctx).Create(time).Error
 	if err != nil {
-		return nil, errorWrap.WrapError(errConstants.ErrSqlQuery)
+		return nil, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
 	}
 	return time, nil
 }
+
+func (t *TimeRepository) FindByStartTimeAndEndTime(ctx context.Context, startTime, endTime string) (*models.Time, error) {
+	var time models.Time
+
+	err := t.db.WithContext(ctx).
+		Where("start_time = ? AND end_time = ?", startTime, endTime).
+		First(&time).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	return &time, nil
+}
+
+// Delete trashes the time slot together with its live schedules, so none of
+// them can still be booked. They share one deleted_at, which is how Restore
+// tells them apart from schedules that were trashed on their own.
+func (t *TimeRepository) Delete(ctx context.Context, uuid string) error {
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var slot models.Time
+		err := tx.Where("uuid = ?", uuid).First(&slot).Error
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		err = tx.Model(&models.FieldSchedule{}).
+			Where("time_id = ?", slot.ID).
+			UpdateColumn("deleted_at", now).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(&slot).UpdateColumn("deleted_at", now).Error
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errorWrap.WrapErrorContext(ctx, errTime.ErrTimeNotFound)
+		}
+
+		return errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	return nil
+}
+
+func (t *TimeRepository) FindAllTrashed(ctx context.Context, params *dto.TrashRequestParam) ([]models.Time, int64, error) {
+	var (
+		times []models.Time
+		total int64
+	)
+
+	query := t.db.WithContext(ctx).Unscoped().Model(&models.Time{}).Where("deleted_at IS NOT NULL").
+		Session(&gorm.Session{})
+	err := query.Count(&total).Error
+	if err != nil {
+		return nil, 0, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	err = query.
+		Order("deleted_at desc").
+		Limit(params.Limit).
+		Offset((params.Page - 1) * params.Limit).
+		Find(&times).Error
+	if err != nil {
+		return nil, 0, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	return times, total, nil
+}
+
+func (t *TimeRepository) FindTrashedByUUID(ctx context.Context, uuid string) (*models.Time, error) {
+	var time models.Time
+
+	err := t.db.WithContext(ctx).Unscoped().
+		Where("uuid = ? AND deleted_at IS NOT NULL", uuid).First(&time).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorWrap.WrapErrorContext(ctx, errTime.ErrTimeNotFound)
+		}
+
+		return nil, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	return &time, nil
+}
+
+// Restore brings the time slot back with the schedules that were trashed along
+// with it. Schedules whose field is still in the trash stay there. The slot
+// index rejects the restore when another live schedule took one of their slots.
+func (t *TimeRepository) Restore(ctx context.Context, uuid string) error {
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var slot models.Time
+		err := tx.Unscoped().Where("uuid = ? AND deleted_at IS NOT NULL", uuid).First(&slot).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Unscoped().
+			Model(&models.FieldSchedule{}).
+			Where("time_id = ? AND deleted_at = ?", slot.ID, slot.DeletedAt.Time).
+			Where("NOT EXISTS (SELECT 1 FROM fields WHERE fields.id = field_schedules.field_id AND fields.deleted_at IS NOT NULL)").
+			UpdateColumn("deleted_at", nil).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Unscoped().Model(&slot).UpdateColumn("deleted_at", nil).Error
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errorWrap.WrapErrorContext(ctx, errTime.ErrTimeNotFound)
+		}
+		if errorWrap.IsUniqueViolation(err, scheduleSlotIndex) {
+			return errorWrap.WrapErrorContext(ctx, errFieldSchedule.ErrFieldShceduleExist)
+		}
+
+		return errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	return nil
+}
+
+// Purge permanently removes time slots trashed before the given time. Their
+// schedules went to the trash with them and are purged first; a slot that a
+// schedule still points to is kept, since removing it would cascade to it.
+func (t *TimeRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	result := t.db.WithContext(ctx).Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Where("NOT EXISTS (SELECT 1 FROM field_schedules WHERE field_schedules.time_id = times.id)").
+		Delete(&models.Time{})
+	if result.Error != nil {
+		return 0, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	return result.RowsAffected, nil
+}
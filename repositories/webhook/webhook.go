@@ -0,0 +1,208 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	errorWrap "field-service/common/error"
+	"field-service/constants"
+	errConstants "field-service/constants/error"
+	errWebhook "field-service/constants/error/webhook"
+	"field-service/domain/dto"
+	"field-service/domain/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WebhookRepository struct {
+	db *gorm.DB
+}
+
+type IWebhookRepository interface {
+	FindAllSubscriptions(context.Context) ([]models.WebhookSubscription, error)
+	FindActiveSubscriptionsByEventType(context.Context, constants.WebhookEventType) ([]models.WebhookSubscription, error)
+	FindSubscriptionByUUID(context.Context, string) (*models.WebhookSubscription, error)
+	CreateSubscription(context.Context, *models.WebhookSubscription) (*models.WebhookSubscription, error)
+	DeleteSubscription(context.Context, string) error
+	FindDeadDeliveriesWithPagination(context.Context, *dto.WebhookDeliveryRequestParam) ([]models.WebhookDelivery, int64, error)
+	FindDeliveryByUUID(context.Context, string) (*models.WebhookDelivery, error)
+	CreateDeliveries(context.Context, []models.WebhookDelivery) error
+	ClaimDueDeliveries(context.Context, int, time.Duration) ([]models.WebhookDelivery, error)
+	UpdateDelivery(context.Context, *models.WebhookDelivery) error
+}
+
+func NewWebhookRepository(db *gorm.DB) IWebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+func (w *WebhookRepository) FindAllSubscriptions(ctx context.Context) ([]models.WebhookSubscription, error) {
+	var subscriptions []models.WebhookSubscription
+	err := w.db.WithContext(ctx).Order("created_at desc").Find(&subscriptions).Error
+	if err != nil {
+		return nil, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	return subscriptions, nil
+}
+
+func (w *WebhookRepository) FindActiveSubscriptionsByEventType(ctx context.Context, eventType constants.WebhookEventType) ([]models.WebhookSubscription, error) {
+	var subscriptions []models.WebhookSubscription
+	err := w.db.WithContext(ctx).
+		Where("is_active = ?", true).
+		Where("? = ANY(event_types) OR ? = ANY(event_types)", string(eventType), string(constants.WebhookAllEvents)).
+		Find(&subscriptions).Error
+	if err != nil {
+		return nil, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	return subscriptions, nil
+}
+
+func (w *WebhookRepository) FindSubscriptionByUUID(ctx context.Context, uuid string) (*models.WebhookSubscription, error) {
+	var subscription models.WebhookSubscription
+	err := w.db.WithContext(ctx).Where("uuid = ?", uuid).First(&subscription).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorWrap.WrapErrorContext(ctx, errWebhook.ErrWebhookNotFound)
+		}
+		return nil, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	return &subscription, nil
+}
+
+func (w *WebhookRepository) CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription) (*models.WebhookSubscription, error) {
+	err := w.db.WithContext(ctx).Create(subscription).Error
+	if err != nil {
+		return nil, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	return subscription, nil
+}
+
+func (w *WebhookRepository) DeleteSubscription(ctx context.Context, uuid string) error {
+	err := w.db.WithContext(ctx).Where("uuid = ?", uuid).Delete(&models.WebhookSubscription{}).Error
+	if err != nil {
+		return errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	return nil
+}
+
+func (w *WebhookRepository) FindDeadDeliveriesWithPagination(ctx context.Context, params *dto.WebhookDeliveryRequestParam) ([]models.WebhookDelivery, int64, error) {
+	var (
+		deliveries []models.WebhookDelivery
+		total      int64
+	)
+
+	limit := params.Limit
+	offset := (params.Page - 1) * params.Limit
+	err := w.db.WithContext(ctx).
+		Preload("Subscription").
+		Where("status = ?", constants.WebhookDead).
+		Limit(limit).
+		Offset(offset).
+		Order("updated_at desc").
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, 0, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	err = w.db.WithContext(ctx).
+		Model(&models.WebhookDelivery{}).
+		Where("status = ?", constants.WebhookDead).
+		Count(&total).Error
+	if err != nil {
+		return nil, 0, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	return deliveries, total, nil
+}
+
+func (w *WebhookRepository) FindDeliveryByUUID(ctx context.Context, uuid string) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	err := w.db.WithContext(ctx).Where("uuid = ?", uuid).First(&delivery).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorWrap.WrapErrorContext(ctx, errWebhook.ErrWebhookDeliveryNotFound)
+		}
+		return nil, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	return &delivery, nil
+}
+
+func (w *WebhookRepository) CreateDeliveries(ctx context.Context, deliveries []models.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	err := w.db.WithContext(ctx).Omit("Subscription").Create(&deliveries).Error
+	if err != nil {
+		return errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	return nil
+}
+
+// ClaimDueDeliveries leases pending deliveries so that concurrent dispatchers skip them.
+func (w *WebhookRepository) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	err := w.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ?", constants.WebhookPending).
+			Where("next_attempt_at <= ?", time.Now()).
+			Order("next_attempt_at asc").
+			Limit(limit).
+			Find(&deliveries).Error
+		if err != nil {
+			return err
+		}
+
+		if len(deliveries) == 0 {
+			return nil
+		}
+
+		ids := make([]uint, 0, len(deliveries))
+		for _, delivery := range deliveries {
+			ids = append(ids, delivery.ID)
+		}
+
+		return tx.Model(&models.WebhookDelivery{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", time.Now().Add(lease)).Error
+	})
+	if err != nil {
+		return nil, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	if len(deliveries) == 0 {
+		return deliveries, nil
+	}
+
+	ids := make([]uint, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		ids = append(ids, delivery.ID)
+	}
+
+	err = w.db.WithContext(ctx).
+		Preload("Subscription").
+		Where("id IN ?", ids).
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	return deliveries, nil
+}
+
+func (w *WebhookRepository) UpdateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	err := w.db.WithContext(ctx).Omit("Subscription").Save(delivery).Error
+	if err != nil {
+		return errorWrap.WrapErrorContext(ctx, errConstants.ErrSqlQuery)
+	}
+
+	return nil
+}
@@ -30,18 +30,28 @@ func NewFieldRoute(controller controllers.IControllerRegistry, group *gin.Router
 func (f *FieldRoute) Run() {
 	group := f.group.Group("/field")
 	group.GET("", middlewares.AuthenticateWithoutToken(), f.controller.GetField().GetAllWithoutPagination)
+	group.GET("/search", middlewares.AuthenticateWithoutToken(), f.controller.GetField().Search)
 	group.GET("/:uuid", middlewares.AuthenticateWithoutToken(), f.controller.GetField().GetByUUID)
 	group.Use(middlewares.Authenticate())
 	group.GET("/pagination", middlewares.
-		CheckRole([]string{constants.Admin, constants.Customer}, f.client),
+		CheckPermission(constants.FieldRead, f.client),
 		f.controller.GetField().GetAllWithPagination)
 	group.POST("", middlewares.
-		CheckRole([]string{constants.Admin}, f.client),
+		CheckPermission(constants.FieldWrite, f.client),
+		middlewares.Idempotency(),
 		f.controller.GetField().Create)
 	group.PUT("/:uuid", middlewares.
-		CheckRole([]string{constants.Admin}, f.client),
+		CheckPermission(constants.FieldWrite, f.client),
+		middlewares.Idempotency(),
 		f.controller.GetField().Update)
 	group.DELETE("/:uuid", middlewares.
-		CheckRole([]string{constants.Admin}, f.client),
+		CheckPermission(constants.FieldWrite, f.client),
 		f.controller.GetField().Delete)
+	group.GET("/trash", middlewares.
+		CheckPermission(constants.TrashManage, f.client),
+		f.controller.GetField().GetTrash)
+	group.POST("/:uuid/restore", middlewares.
+		CheckPermission(constants.TrashManage, f.client),
+		middlewares.Idempotency(),
+		f.controller.GetField().Restore)
 }
@@ -30,24 +30,36 @@ func NewFieldScheduleRoute(controller controllers.IControllerRegistry, group *gi
 func (f *FieldScheduleRoute) Run() {
 	group := f.group.Group("/field/schedule")
 	group.GET("/lists/:uuid", middlewares.AuthenticateWithoutToken(), f.controller.GetFieldSchedule().GetAllByFieldIDAndDate)
-	group.PATCH("/status", middlewares.AuthenticateWithoutToken(), f.controller.GetFieldSchedule().UpdateStatus)
+	group.PATCH("/status", middlewares.AuthenticateWithoutToken(),
+		middlewares.Idempotency(),
+		f.controller.GetFieldSchedule().UpdateStatus)
 	group.Use(middlewares.Authenticate())
 	group.GET("/pagination", middlewares.
-		CheckRole([]string{constants.Admin, constants.Customer}, f.client),
+		CheckPermission(constants.ScheduleRead, f.client),
 		f.controller.GetFieldSchedule().GetAllWithPagination)
 	group.GET("/:uuid", middlewares.
-		CheckRole([]string{constants.Admin, constants.Customer}, f.client),
+		CheckPermission(constants.ScheduleRead, f.client),
 		f.controller.GetFieldSchedule().GetByUUID)
 	group.POST("", middlewares.
-		CheckRole([]string{constants.Admin}, f.client),
+		CheckPermission(constants.ScheduleWrite, f.client),
+		middlewares.Idempotency(),
 		f.controller.GetFieldSchedule().Create)
 	group.PUT("/:uuid", middlewares.
-		CheckRole([]string{constants.Admin}, f.client),
+		CheckPermission(constants.ScheduleWrite, f.client),
+		middlewares.Idempotency(),
 		f.controller.GetFieldSchedule().Update)
 	group.POST("/one-month", middlewares.
-		CheckRole([]string{constants.Admin}, f.client),
+		CheckPermission(constants.ScheduleGenerate, f.client),
+		middlewares.Idempotency(),
 		f.controller.GetFieldSchedule().GenerateScheduleForOneMonth)
 	group.DELETE("/:uuid", middlewares.
-		CheckRole([]string{constants.Admin}, f.client),
+		CheckPermission(constants.ScheduleWrite, f.client),
 		f.controller.GetFieldSchedule().Delete)
+	group.GET("/trash", middlewares.
+		CheckPermission(constants.TrashManage, f.client),
+		f.controller.GetFieldSchedule().GetTrash)
+	group.POST("/:uuid/restore", middlewares.
+		CheckPermission(constants.TrashManage, f.client),
+		middlewares.Idempotency(),
+		f.controller.GetFieldSchedule().Restore)
 }
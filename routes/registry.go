@@ -3,9 +3,11 @@ package routes
 import (
 	"field-service/clients"
 	"field-service/controllers"
+	auditLogRoute "field-service/routes/auditlog"
 	fieldRoute "field-service/routes/field"
 	fieldScheduleRoute "field-service/routes/fieldschedule"
 	timeRoute "field-service/routes/time"
+	webhookRoute "field-service/routes/webhook"
 
 	"github.com/gin-gonic/gin"
 )
@@ -40,8 +42,18 @@ func (r *Registry) timeRoute() timeRoute.ITimeRoute {
 	return timeRoute.NewTimeRoute(r.controller, r.group, r.client)
 }
 
+func (r *Registry) auditLogRoute() auditLogRoute.IAuditLogRoute {
+	return auditLogRoute.NewAuditLogRoute(r.controller, r.group, r.client)
+}
+
+func (r *Registry) webhookRoute() webhookRoute.IWebhookRoute {
+	return webhookRoute.NewWebhookRoute(r.controller, r.group, r.client)
+}
+
 func (r *Registry) Serve() {
 	r.fieldRoute().Run()
 	r.fieldScheduleRoute().Run()
 	r.timeRoute().Run()
+	r.auditLogRoute().Run()
+	r.webhookRoute().Run()
 }
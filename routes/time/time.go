@@ -31,12 +31,23 @@ func (t *TimeRoute) Run() {
 	group := t.group.Group("/time")
 	group.Use(middlewares.Authenticate())
 	group.GET("", middlewares.
-		CheckRole([]string{constants.Admin}, t.client),
+		CheckPermission(constants.TimeRead, t.client),
 		t.controller.GetTime().GetAll)
 	group.POST("", middlewares.
-		CheckRole([]string{constants.Admin}, t.client),
+		CheckPermission(constants.TimeWrite, t.client),
+		middlewares.Idempotency(),
 		t.controller.GetTime().Create)
 	group.GET("/:uuid", middlewares.
-		CheckRole([]string{constants.Admin}, t.client),
+		CheckPermission(constants.TimeRead, t.client),
 		t.controller.GetTime().GetByUUID)
+	group.DELETE("/:uuid", middlewares.
+		CheckPermission(constants.TimeWrite, t.client),
+		t.controller.GetTime().Delete)
+	group.GET("/trash", middlewares.
+		CheckPermission(constants.TrashManage, t.client),
+		t.controller.GetTime().GetTrash)
+	group.POST("/:uuid/restore", middlewares.
+		CheckPermission(constants.TrashManage, t.client),
+		middlewares.Idempotency(),
+		t.controller.GetTime().Restore)
 }
@@ -0,0 +1,50 @@
+package routes
+
+import (
+	"field-service/clients"
+	"field-service/constants"
+	"field-service/controllers"
+	"field-service/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookRoute struct {
+	controller controllers.IControllerRegistry
+	group      *gin.RouterGroup
+	client     clients.IClientRegistry
+}
+
+type IWebhookRoute interface {
+	Run()
+}
+
+func NewWebhookRoute(controller controllers.IControllerRegistry, group *gin.RouterGroup, client clients.IClientRegistry) IWebhookRoute {
+	return &WebhookRoute{
+		controller: controller,
+		group:      group,
+		client:     client,
+	}
+}
+
+func (w *WebhookRoute) Run() {
+	group := w.group.Group("/webhook")
+	group.Use(middlewares.Authenticate())
+	group.GET("", middlewares.
+		CheckPermission(constants.WebhookManage, w.client),
+		w.controller.GetWebhook().GetAllSubscriptions)
+	group.POST("", middlewares.
+		CheckPermission(constants.WebhookManage, w.client),
+		middlewares.Idempotency(),
+		w.controller.GetWebhook().CreateSubscription)
+	group.DELETE("/:uuid", middlewares.
+		CheckPermission(constants.WebhookManage, w.client),
+		w.controller.GetWebhook().DeleteSubscription)
+	group.GET("/dead-letter", middlewares.
+		CheckPermission(constants.WebhookManage, w.client),
+		w.controller.GetWebhook().GetDeadLetters)
+	group.POST("/dead-letter/:uuid/replay", middlewares.
+		CheckPermission(constants.WebhookManage, w.client),
+		middlewares.Idempotency(),
+		w.controller.GetWebhook().Replay)
+}
@@ -0,0 +1,186 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	clients "field-service/clients/users"
+	"field-service/common/logger"
+	"field-service/common/util"
+	"field-service/constants"
+	"field-service/domain/dto"
+	"field-service/domain/models"
+	"field-service/repositories"
+	"reflect"
+
+	"github.com/google/uuid"
+)
+
+type AuditLogService struct {
+	repositories repositories.IRepostitoryRegistry
+}
+
+type IAuditLogService interface {
+	GetAllWithPagination(context.Context, *dto.AuditLogRequestParam) (*util.PaginationResult, error)
+	Record(context.Context, ...dto.AuditLogRecord)
+}
+
+func NewAuditLogService(repositories repositories.IRepostitoryRegistry) IAuditLogService {
+	return &AuditLogService{repositories: repositories}
+}
+
+func (a *AuditLogService) GetAllWithPagination(ctx context.Context, param *dto.AuditLogRequestParam) (*util.PaginationResult, error) {
+	auditLogs, total, err := a.repositories.GetAuditLogRepository().FindAllWithPagination(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+
+	auditLogResults := make([]dto.AuditLogResponse, 0, len(auditLogs))
+	for _, auditLog := range auditLogs {
+		auditLogResults = append(auditLogResults, dto.AuditLogResponse{
+			UUID:        auditLog.UUID,
+			ActorUUID:   auditLog.ActorUUID,
+			ActorName:   auditLog.ActorName,
+			ActorRole:   auditLog.ActorRole,
+			ServiceName: auditLog.ServiceName,
+			Action:      auditLog.Action,
+			Entity:      auditLog.Entity,
+			EntityUUID:  auditLog.EntityUUID,
+			Before:      json.RawMessage(auditLog.Before),
+			After:       json.RawMessage(auditLog.After),
+			Diff:        json.RawMessage(auditLog.Diff),
+			RequestID:   auditLog.RequestID,
+			SourceIP:    auditLog.SourceIP,
+			CreatedAt:   auditLog.CreatedAt,
+		})
+	}
+
+	pagination := &util.PaginationParam{
+		Page:  param.Page,
+		Limit: param.Limit,
+		Count: total,
+		Data:  auditLogResults,
+	}
+
+	response := util.GeneratePagination(*pagination)
+
+	return &response, nil
+}
+
+// snapshot flattens a model into its scalar columns; preloaded relations are dropped.
+func (a *AuditLogService) snapshot(value any) map[string]any {
+	if value == nil || (reflect.ValueOf(value).Kind() == reflect.Pointer && reflect.ValueOf(value).IsNil()) {
+		return nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+
+	var result map[string]any
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		return nil
+	}
+
+	for key, item := range result {
+		switch v := item.(type) {
+		case map[string]any:
+			delete(result, key)
+		case []any:
+			if len(v) > 0 {
+				if _, ok := v[0].(map[string]any); ok {
+					delete(result, key)
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+func (a *AuditLogService) diff(before, after map[string]any) map[string]any {
+	diff := make(map[string]any)
+	keys := make(map[string]struct{}, len(before)+len(after))
+	for key := range before {
+		keys[key] = struct{}{}
+	}
+	for key := range after {
+		keys[key] = struct{}{}
+	}
+
+	for key := range keys {
+		if key == "UpdatedAt" {
+			continue
+		}
+
+		if !reflect.DeepEqual(before[key], after[key]) {
+			diff[key] = map[string]any{
+				"before": before[key],
+				"after":  after[key],
+			}
+		}
+	}
+
+	return diff
+}
+
+func (a *AuditLogService) toJSON(value any) models.JSON {
+	if value == nil || reflect.ValueOf(value).IsNil() {
+		return nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+
+	return models.JSON(data)
+}
+
+func (a *AuditLogService) Record(ctx context.Context, records ...dto.AuditLogRecord) {
+	var (
+		actorUUID *uuid.UUID
+		actorName string
+		actorRole string
+	)
+
+	user, ok := ctx.Value(constants.User).(*clients.UserData)
+	if ok {
+		actorUUID = &user.UUID
+		actorName = user.Name
+		actorRole = user.Role
+	}
+
+	serviceName, _ := ctx.Value(constants.ServiceName).(string)
+	requestID, _ := ctx.Value(constants.RequestID).(string)
+	sourceIP, _ := ctx.Value(constants.SourceIP).(string)
+
+	auditLogs := make([]models.AuditLog, 0, len(records))
+	for _, record := range records {
+		before := a.snapshot(record.Before)
+		after := a.snapshot(record.After)
+		auditLogs = append(auditLogs, models.AuditLog{
+			UUID:        uuid.New(),
+			ActorUUID:   actorUUID,
+			ActorName:   actorName,
+			ActorRole:   actorRole,
+			ServiceName: serviceName,
+			Action:      string(record.Action),
+			Entity:      string(record.Entity),
+			EntityUUID:  record.EntityUUID,
+			Before:      a.toJSON(before),
+			After:       a.toJSON(after),
+			Diff:        a.toJSON(a.diff(before, after)),
+			RequestID:   requestID,
+			SourceIP:    sourceIP,
+		})
+	}
+
+	// An audit failure must not undo a change that has already been committed,
+	// and a client hanging up afterwards must not drop the entry either.
+	err := a.repositories.GetAuditLogRepository().Create(context.WithoutCancel(ctx), auditLogs)
+	if err != nil {
+		logger.FromContext(ctx).Errorf("failed to write audit log: %v", err)
+	}
+}
@@ -4,45 +4,83 @@ import (
 	"bytes"
 	"context"
 	gcs "field-service/common/gcs"
+	"field-service/common/logger"
+	"field-service/common/query"
 	"field-service/common/util"
+	"field-service/constants"
 	errConstant "field-service/constants/error"
 	"field-service/domain/dto"
 	"field-service/domain/models"
 	"field-service/repositories"
+	auditLogService "field-service/services/auditlog"
+	webhookService "field-service/services/webhook"
 	"fmt"
 	"io"
 	"mime/multipart"
 	"path"
 	"time"
-
-	"github.com/sirupsen/logrus"
 )
 
 type FieldService struct {
 	repositories repositories.IRepostitoryRegistry
 	gcs          gcs.IGCSClient
+	auditLog     auditLogService.IAuditLogService
+	webhook      webhookService.IWebhookService
 }
 
 type IFieldService interface {
 	GetAllWithPagination(context.Context, *dto.FieldRequestParam) (*util.PaginationResult, error)
+	GetAllWithCursor(context.Context, *dto.FieldRequestParam) (*util.CursorPaginationResult, error)
 	GetAllWithoutPagination(context.Context) ([]dto.FieldResponse, error)
+	Search(context.Context, *dto.FieldSearchRequestParam) (*util.PaginationResult, error)
 	GetByUUID(context.Context, string) (*dto.FieldResponse, error)
+	GetAllInternal(context.Context) ([]dto.InternalFieldResponse, error)
+	GetInternalByUUID(context.Context, string) (*dto.InternalFieldResponse, error)
 	Create(context.Context, *dto.FieldRequest) (*dto.FieldResponse, error)
 	Update(context.Context, string, *dto.UpdateFieldRequest) (*dto.FieldResponse, error)
 	Delete(context.Context, string) error
+	GetTrash(context.Context, *dto.TrashRequestParam) (*util.PaginationResult, error)
+	Restore(context.Context, string) (*dto.FieldResponse, error)
 }
 
 func NewFieldService(
 	repositories repositories.IRepostitoryRegistry,
 	gcs gcs.IGCSClient,
+	auditLog auditLogService.IAuditLogService,
+	webhook webhookService.IWebhookService,
 ) IFieldService {
 	return &FieldService{
 		repositories: repositories,
 		gcs:          gcs,
+		auditLog:     auditLog,
+		webhook:      webhook,
 	}
 }
 
+// inTransaction runs fn with a copy of the service bound to one transaction,
+// so the change, its audit log and its webhook deliveries commit together.
+func (f *FieldService) inTransaction(ctx context.Context, fn func(*FieldService) error) error {
+	return f.repositories.Transaction(ctx, func(tx repositories.IRepostitoryRegistry) error {
+		return fn(&FieldService{
+			repositories: tx,
+			gcs:          f.gcs,
+			auditLog:     auditLogService.NewAuditLogService(tx),
+			webhook:      webhookService.NewWebhookService(tx),
+		})
+	})
+}
+
+// checkFilter rejects a price range whose minimum is above its maximum.
+func (f *FieldService) checkFilter(param *dto.FieldRequestParam) error {
+	return query.CheckRange(param.MinPrice, param.MaxPrice)
+}
+
 func (f *FieldService) GetAllWithPagination(ctx context.Context, param *dto.FieldRequestParam) (*util.PaginationResult, error) {
+	err := f.checkFilter(param)
+	if err != nil {
+		return nil, err
+	}
+
 	fields, total, err := f.repositories.GetFieldRepository().FindAllWithPagination(ctx, param)
 	if err != nil {
 		return nil, err
@@ -73,6 +111,84 @@ func (f *FieldService) GetAllWithPagination(ctx context.Context, param *dto.Fiel
 	return &responses, nil
 }
 
+func (f *FieldService) GetAllWithCursor(ctx context.Context, param *dto.FieldRequestParam) (*util.CursorPaginationResult, error) {
+	err := f.checkFilter(param)
+	if err != nil {
+		return nil, err
+	}
+
+	fields, next, err := f.repositories.GetFieldRepository().FindAllWithCursor(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldResults := make([]dto.FieldResponse, 0, len(fields))
+	for _, field := range fields {
+		fieldResults = append(fieldResults, dto.FieldResponse{
+			UUID:         field.UUID,
+			Code:         field.Code,
+			Name:         field.Name,
+			PricePerHour: field.PricePerHour,
+			Images:       field.Images,
+			CreatedAt:    field.CreatedAt,
+			UpdatedAt:    field.UpdatedAt,
+		})
+	}
+
+	response := util.CursorPaginationResult{
+		Limit: param.Limit,
+		Data:  fieldResults,
+	}
+	if next != "" {
+		response.NextCursor = &next
+	}
+
+	if param.WithCount {
+		total, err := f.repositories.GetFieldRepository().Count(ctx, param)
+		if err != nil {
+			return nil, err
+		}
+		response.TotalData = &total
+	}
+
+	return &response, nil
+}
+
+func (f *FieldService) Search(ctx context.Context, param *dto.FieldSearchRequestParam) (*util.PaginationResult, error) {
+	results, total, err := f.repositories.GetFieldRepository().Search(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+
+	searchResults := make([]dto.FieldSearchResponse, 0, len(results))
+	for _, result := range results {
+		searchResults = append(searchResults, dto.FieldSearchResponse{
+			FieldResponse: dto.FieldResponse{
+				UUID:         result.UUID,
+				Code:         result.Code,
+				Name:         result.Name,
+				PricePerHour: result.PricePerHour,
+				Images:       result.Images,
+				CreatedAt:    result.CreatedAt,
+				UpdatedAt:    result.UpdatedAt,
+			},
+			Rank:    result.Rank,
+			Snippet: result.Snippet,
+		})
+	}
+
+	pagination := &util.PaginationParam{
+		Page:  param.Page,
+		Count: total,
+		Limit: param.Limit,
+		Data:  searchResults,
+	}
+
+	response := util.GeneratePagination(*pagination)
+
+	return &response, nil
+}
+
 func (f *FieldService) GetAllWithoutPagination(ctx context.Context) ([]dto.FieldResponse, error) {
 	fields, err := f.repositories.GetFieldRepository().FindAllWithoutPagination(ctx)
 	if err != nil {
@@ -115,6 +231,42 @@ func (f *FieldService) GetByUUID(ctx context.Context, uuid string) (*dto.FieldRe
 	return &fieldResult, nil
 }
 
+func (f *FieldService) toInternalResponse(field *models.Field) dto.InternalFieldResponse {
+	return dto.InternalFieldResponse{
+		UUID:         field.UUID,
+		Code:         field.Code,
+		Name:         field.Name,
+		PricePerHour: field.PricePerHour,
+		Images:       field.Images,
+		CreatedAt:    field.CreatedAt,
+		UpdatedAt:    field.UpdatedAt,
+	}
+}
+
+func (f *FieldService) GetAllInternal(ctx context.Context) ([]dto.InternalFieldResponse, error) {
+	fields, err := f.repositories.GetFieldRepository().FindAllWithoutPagination(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldResults := make([]dto.InternalFieldResponse, 0, len(fields))
+	for i := range fields {
+		fieldResults = append(fieldResults, f.toInternalResponse(&fields[i]))
+	}
+
+	return fieldResults, nil
+}
+
+func (f *FieldService) GetInternalByUUID(ctx context.Context, uuid string) (*dto.InternalFieldResponse, error) {
+	field, err := f.repositories.GetFieldRepository().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldResult := f.toInternalResponse(field)
+	return &fieldResult, nil
+}
+
 func (f *FieldService) validateUpload(images []multipart.FileHeader) error {
 	if images == nil || len(images) == 0 {
 		return errConstant.ErrInvalidUploadFile
@@ -173,7 +325,7 @@ func (f *FieldService) uploadImage(ctx context.Context, images []multipart.FileH
 func (f *FieldService) Create(ctx context.Context, req *dto.FieldRequest) (*dto.FieldResponse, error) {
 	imageUrl, err := f.uploadImage(ctx, req.Images)
 	if err != nil {
-		logrus.Errorf("Fieldservice Create - 1 %v", err)
+		logger.FromContext(ctx).Errorf("Fieldservice Create - 1 %v", err)
 		return nil, err
 	}
 
@@ -184,10 +336,17 @@ func (f *FieldService) Create(ctx context.Context, req *dto.FieldRequest) (*dto.
 		Images:       imageUrl,
 	})
 	if err != nil {
-		logrus.Errorf("Fieldservice Create - 2 %v", err)
+		logger.FromContext(ctx).Errorf("Fieldservice Create - 2 %v", err)
 		return nil, err
 	}
 
+	f.auditLog.Record(ctx, dto.AuditLogRecord{
+		Action:     constants.AuditCreate,
+		Entity:     constants.AuditField,
+		EntityUUID: field.UUID,
+		After:      field,
+	})
+
 	response := dto.FieldResponse{
 		UUID:         field.UUID,
 		Code:         field.Code,
@@ -207,19 +366,53 @@ func (f *FieldService) Update(ctx context.Context, uuid string, req *dto.UpdateF
 		return nil, err
 	}
 
+	images := field.Images
 	if req.Images != nil && len(req.Images) > 0 {
 		imageUrl, err := f.uploadImage(ctx, req.Images)
 		if err != nil {
 			return nil, err
 		}
-		field.Images = imageUrl
+		images = imageUrl
 	}
 
-	fieldResult, err := f.repositories.GetFieldRepository().Update(ctx, uuid, &models.Field{
-		Name:         req.Name,
-		Code:         req.Code,
-		PricePerHour: req.PricePerHour,
-		Images:       field.Images,
+	before := *field
+	var fieldResult *models.Field
+	err = f.inTransaction(ctx, func(tx *FieldService) error {
+		fieldResult, err = tx.repositories.GetFieldRepository().Update(ctx, uuid, &models.Field{
+			Name:         req.Name,
+			Code:         req.Code,
+			PricePerHour: req.PricePerHour,
+			Images:       images,
+		})
+		if err != nil {
+			return err
+		}
+
+		after := before
+		after.Name = fieldResult.Name
+		after.Code = fieldResult.Code
+		after.PricePerHour = fieldResult.PricePerHour
+		after.Images = fieldResult.Images
+		after.UpdatedAt = fieldResult.UpdatedAt
+		tx.auditLog.Record(ctx, dto.AuditLogRecord{
+			Action:     constants.AuditUpdate,
+			Entity:     constants.AuditField,
+			EntityUUID: before.UUID,
+			Before:     &before,
+			After:      &after,
+		})
+
+		if before.PricePerHour == after.PricePerHour {
+			return nil
+		}
+
+		return tx.webhook.Publish(ctx, constants.WebhookFieldPriceChanged, dto.FieldPriceChangedEventData{
+			UUID:                 after.UUID,
+			Code:                 after.Code,
+			Name:                 after.Name,
+			PreviousPricePerHour: before.PricePerHour,
+			PricePerHour:         after.PricePerHour,
+		})
 	})
 	if err != nil {
 		return nil, err
@@ -239,7 +432,7 @@ func (f *FieldService) Update(ctx context.Context, uuid string, req *dto.UpdateF
 }
 
 func (f *FieldService) Delete(ctx context.Context, uuid string) error {
-	_, err := f.repositories.GetFieldRepository().FindByUUID(ctx, uuid)
+	field, err := f.repositories.GetFieldRepository().FindByUUID(ctx, uuid)
 	if err != nil {
 		return err
 	}
@@ -249,5 +442,78 @@ func (f *FieldService) Delete(ctx context.Context, uuid string) error {
 		return err
 	}
 
+	f.auditLog.Record(ctx, dto.AuditLogRecord{
+		Action:     constants.AuditDelete,
+		Entity:     constants.AuditField,
+		EntityUUID: field.UUID,
+		Before:     field,
+	})
+
 	return nil
 }
+
+func (f *FieldService) GetTrash(ctx context.Context, param *dto.TrashRequestParam) (*util.PaginationResult, error) {
+	fields, total, err := f.repositories.GetFieldRepository().FindAllTrashed(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldResults := make([]dto.FieldResponse, 0, len(fields))
+	for _, field := range fields {
+		fieldResults = append(fieldResults, dto.FieldResponse{
+			UUID:         field.UUID,
+			Code:         field.Code,
+			Name:         field.Name,
+			PricePerHour: field.PricePerHour,
+			Images:       field.Images,
+			CreatedAt:    field.CreatedAt,
+			UpdatedAt:    field.UpdatedAt,
+			DeletedAt:    util.DeletedAtTime(field.DeletedAt),
+		})
+	}
+
+	pagination := &util.PaginationParam{
+		Page:  param.Page,
+		Count: total,
+		Limit: param.Limit,
+		Data:  fieldResults,
+	}
+
+	response := util.GeneratePagination(*pagination)
+
+	return &response, nil
+}
+
+func (f *FieldService) Restore(ctx context.Context, uuid string) (*dto.FieldResponse, error) {
+	field, err := f.repositories.GetFieldRepository().FindTrashedByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	err = f.repositories.GetFieldRepository().Restore(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	before := *field
+	field.DeletedAt = nil
+	f.auditLog.Record(ctx, dto.AuditLogRecord{
+		Action:     constants.AuditRestore,
+		Entity:     constants.AuditField,
+		EntityUUID: field.UUID,
+		Before:     &before,
+		After:      field,
+	})
+
+	response := dto.FieldResponse{
+		UUID:         field.UUID,
+		Code:         field.Code,
+		Name:         field.Name,
+		PricePerHour: field.PricePerHour,
+		Images:       field.Images,
+		CreatedAt:    field.CreatedAt,
+		UpdatedAt:    field.UpdatedAt,
+	}
+
+	return &response, nil
+}
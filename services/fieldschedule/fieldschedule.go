@@ -2,12 +2,19 @@ package services
 
 import (
 	"context"
+	"field-service/common/logger"
+	"field-service/common/metrics"
+	"field-service/common/query"
 	"field-service/common/util"
 	"field-service/constants"
+	errField "field-service/constants/error/field"
 	errFieldSchedule "field-service/constants/error/field_schedule"
+	errTime "field-service/constants/error/time"
 	"field-service/domain/dto"
 	"field-service/domain/models"
 	"field-service/repositories"
+	auditLogService "field-service/services/auditlog"
+	webhookService "field-service/services/webhook"
 	"fmt"
 	"time"
 
@@ -16,24 +23,98 @@ import (
 
 type FieldScheduleService struct {
 	repositories repositories.IRepostitoryRegistry
+	auditLog     auditLogService.IAuditLogService
+	webhook      webhookService.IWebhookService
 }
 
 type IFieldScheduleService interface {
 	GetAllWithPagination(context.Context, *dto.FieldScheduleRequestParam) (*util.PaginationResult, error)
+	GetAllWithCursor(context.Context, *dto.FieldScheduleRequestParam) (*util.CursorPaginationResult, error)
 	GetAllByFieldIDAndDate(context.Context, string, string) ([]dto.FieldScheduleForBookingResponse, error)
 	GetByUUID(context.Context, string) (*dto.FieldScheduleReponse, error)
+	GetAllInternalByFieldIDAndDate(context.Context, string, string) ([]dto.InternalFieldScheduleResponse, error)
+	GetInternalByUUID(context.Context, string) (*dto.InternalFieldScheduleResponse, error)
 	GenerateScheduleForOneMonth(context.Context, *dto.GenerateFieldScheduleForOneMonthRequest) error
 	Create(context.Context, *dto.FieldScheduleRequest) error
 	Update(context.Context, string, *dto.UpdateFieldScheduleRequest) (*dto.FieldScheduleReponse, error)
 	UpdateStatus(context.Context, *dto.UpdateStatusFieldScheduleRequest) error
+	SetStatus(context.Context, constants.FieldScheduleStatus, *uuid.UUID, []string) error
 	Delete(context.Context, string) error
+	GetTrash(context.Context, *dto.TrashRequestParam) (*util.PaginationResult, error)
+	Restore(context.Context, string) (*dto.FieldScheduleReponse, error)
 }
 
-func NewFieldScheduleService(repositories repositories.IRepostitoryRegistry) IFieldScheduleService {
-	return &FieldScheduleService{repositories: repositories}
+func NewFieldScheduleService(
+	repositories repositories.IRepostitoryRegistry,
+	auditLog auditLogService.IAuditLogService,
+	webhook webhookService.IWebhookService,
+) IFieldScheduleService {
+	return &FieldScheduleService{
+		repositories: repositories,
+		auditLog:     auditLog,
+		webhook:      webhook,
+	}
+}
+
+func (f *FieldScheduleService) toEventData(fieldSchedule *models.FieldSchedule, scheduleTime *models.Time) dto.FieldScheduleEventData {
+	return dto.FieldScheduleEventData{
+		UUID:      fieldSchedule.UUID,
+		FieldUUID: fieldSchedule.Field.UUID,
+		Date:      fieldSchedule.Date.Format(time.DateOnly),
+		StartTime: scheduleTime.StartTime,
+		EndTime:   scheduleTime.EndTime,
+		Status:    fieldSchedule.Status.GetStatusString(),
+	}
+}
+
+// inTransaction runs fn with a copy of the service bound to one transaction,
+// so the change, its audit log and its webhook deliveries commit together.
+func (f *FieldScheduleService) inTransaction(ctx context.Context, fn func(*FieldScheduleService) error) error {
+	return f.repositories.Transaction(ctx, func(tx repositories.IRepostitoryRegistry) error {
+		return fn(&FieldScheduleService{
+			repositories: tx,
+			auditLog:     auditLogService.NewAuditLogService(tx),
+			webhook:      webhookService.NewWebhookService(tx),
+		})
+	})
+}
+
+func (f *FieldScheduleService) recordCreated(ctx context.Context, fieldSchedules []models.FieldSchedule) {
+	records := make([]dto.AuditLogRecord, 0, len(fieldSchedules))
+	for i := range fieldSchedules {
+		records = append(records, dto.AuditLogRecord{
+			Action:     constants.AuditCreate,
+			Entity:     constants.AuditFieldSchedule,
+			EntityUUID: fieldSchedules[i].UUID,
+			After:      &fieldSchedules[i],
+		})
+	}
+
+	f.auditLog.Record(ctx, records...)
+}
+
+// checkFilter rejects date, time and price ranges whose lower bound is past
+// their upper bound.
+func (f *FieldScheduleService) checkFilter(param *dto.FieldScheduleRequestParam) error {
+	err := query.CheckRange(param.StartDate, param.EndDate)
+	if err != nil {
+		return err
+	}
+
+	err = query.CheckRange(param.StartTime, param.EndTime)
+	if err != nil {
+		return err
+	}
+
+	return query.CheckRange(param.MinPrice, param.MaxPrice)
 }
 
 func (f *FieldScheduleService) GetAllWithPagination(ctx context.Context, param *dto.FieldScheduleRequestParam) (*util.PaginationResult, error) {
+	err := f.checkFilter(param)
+	if err != nil {
+		return nil, err
+	}
+
 	fieldSchedules, total, err := f.repositories.GetFieldScheduleRepository().FindAllWithPagination(ctx, param)
 	if err != nil {
 		return nil, err
@@ -65,6 +146,50 @@ func (f *FieldScheduleService) GetAllWithPagination(ctx context.Context, param *
 	return &response, nil
 }
 
+func (f *FieldScheduleService) GetAllWithCursor(ctx context.Context, param *dto.FieldScheduleRequestParam) (*util.CursorPaginationResult, error) {
+	err := f.checkFilter(param)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldSchedules, next, err := f.repositories.GetFieldScheduleRepository().FindAllWithCursor(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldSchedulesResults := make([]dto.FieldScheduleReponse, 0, len(fieldSchedules))
+	for _, schedule := range fieldSchedules {
+		fieldSchedulesResults = append(fieldSchedulesResults, dto.FieldScheduleReponse{
+			UUID:         schedule.UUID,
+			FieldName:    schedule.Field.Name,
+			Date:         schedule.Date.Format("2006-01-02"),
+			PricePerHour: schedule.Field.PricePerHour,
+			Status:       schedule.Status.GetStatusString(),
+			Time:         fmt.Sprintf("%s - %s", schedule.Time.StartTime, schedule.Time.EndTime),
+			CreatedAt:    schedule.CreatedAt,
+			UpdatedAt:    schedule.UpdatedAt,
+		})
+	}
+
+	response := util.CursorPaginationResult{
+		Limit: param.Limit,
+		Data:  fieldSchedulesResults,
+	}
+	if next != "" {
+		response.NextCursor = &next
+	}
+
+	if param.WithCount {
+		total, err := f.repositories.GetFieldScheduleRepository().Count(ctx, param)
+		if err != nil {
+			return nil, err
+		}
+		response.TotalData = &total
+	}
+
+	return &response, nil
+}
+
 func (f *FieldScheduleService) convertMonthName(inputDate string) string {
 	date, err := time.Parse(time.DateOnly, inputDate)
 	if err != nil {
@@ -140,6 +265,51 @@ func (f *FieldScheduleService) GetByUUID(ctx context.Context, uuid string) (*dto
 	return &response, nil
 }
 
+func (f *FieldScheduleService) toInternalResponse(fieldSchedule *models.FieldSchedule) dto.InternalFieldScheduleResponse {
+	return dto.InternalFieldScheduleResponse{
+		UUID:         fieldSchedule.UUID,
+		FieldUUID:    fieldSchedule.Field.UUID,
+		FieldName:    fieldSchedule.Field.Name,
+		Date:         fieldSchedule.Date,
+		PricePerHour: fieldSchedule.Field.PricePerHour,
+		Status:       fieldSchedule.Status,
+		TimeUUID:     fieldSchedule.Time.UUID,
+		StartTime:    fieldSchedule.Time.StartTime,
+		EndTime:      fieldSchedule.Time.EndTime,
+		CreatedAt:    fieldSchedule.CreatedAt,
+		UpdatedAt:    fieldSchedule.UpdatedAt,
+	}
+}
+
+func (f *FieldScheduleService) GetAllInternalByFieldIDAndDate(ctx context.Context, uuid, date string) ([]dto.InternalFieldScheduleResponse, error) {
+	field, err := f.repositories.GetFieldRepository().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldSchedules, err := f.repositories.GetFieldScheduleRepository().FindAllByFieldIDAndDate(ctx, int(field.ID), date)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldSchedulesResults := make([]dto.InternalFieldScheduleResponse, 0, len(fieldSchedules))
+	for i := range fieldSchedules {
+		fieldSchedulesResults = append(fieldSchedulesResults, f.toInternalResponse(&fieldSchedules[i]))
+	}
+
+	return fieldSchedulesResults, nil
+}
+
+func (f *FieldScheduleService) GetInternalByUUID(ctx context.Context, uuid string) (*dto.InternalFieldScheduleResponse, error) {
+	fieldSchedule, err := f.repositories.GetFieldScheduleRepository().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	response := f.toInternalResponse(fieldSchedule)
+	return &response, nil
+}
+
 func (f *FieldScheduleService) Create(ctx context.Context, request *dto.FieldScheduleRequest) error {
 	// cek field schedule berdasarkan uuid ada atau tidak
 	field, err := f.repositories.GetFieldRepository().FindByUUID(ctx, request.FieldID)
@@ -180,8 +350,11 @@ func (f *FieldScheduleService) Create(ctx context.Context, request *dto.FieldSch
 	// masukan fieldSchedules yang ada diarray ke repository
 	err = f.repositories.GetFieldScheduleRepository().Create(ctx, fieldSchedules)
 	if err != nil {
-		return nil
+		return err
 	}
+
+	f.recordCreated(ctx, fieldSchedules)
+	metrics.FieldSchedulesGeneratedTotal.WithLabelValues(metrics.SourceManual).Add(float64(len(fieldSchedules)))
 	// return hasil
 	return nil
 
@@ -241,6 +414,9 @@ func (f *FieldScheduleService) GenerateScheduleForOneMonth(ctx context.Context,
 	if err != nil {
 		return err
 	}
+
+	f.recordCreated(ctx, fieldSchedules)
+	metrics.FieldSchedulesGeneratedTotal.WithLabelValues(metrics.SourceGenerate).Add(float64(len(fieldSchedules)))
 	// return hasil
 	return nil
 }
@@ -269,18 +445,35 @@ func (f *FieldScheduleService) Update(ctx context.Context, uuid string, request
 		return nil, err
 	}
 
-	// cek apakah field schedule dengan waktu dan tanggal tertentu ada, dan request date tidak sama dengan tanggal fieldSchedule
-	if isTimeExist != nil && request.Date != fieldSchedule.Date.Format(time.DateOnly) {
+	// cek apakah slot tanggal dan waktu request sudah dipakai field schedule lain
+	if isTimeExist != nil && isTimeExist.ID != fieldSchedule.ID {
 		// jika iya return error fieldschedule sudah ada
 		return nil, errFieldSchedule.ErrFieldShceduleExist
 	}
 
+	before := *fieldSchedule
+
 	// parsing date request
 	dateParsed, _ := time.Parse(time.DateOnly, request.Date)
 	// masukan date request, models field schedule ke repository field schedule update
-	fieldResult, err := f.repositories.GetFieldScheduleRepository().Update(ctx, uuid, &models.FieldSchedule{
-		Date:   dateParsed,
-		TimeID: scheduleTime.ID,
+	var fieldResult *models.FieldSchedule
+	err = f.inTransaction(ctx, func(tx *FieldScheduleService) error {
+		fieldResult, err = tx.repositories.GetFieldScheduleRepository().Update(ctx, uuid, &models.FieldSchedule{
+			Date:   dateParsed,
+			TimeID: scheduleTime.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		tx.auditLog.Record(ctx, dto.AuditLogRecord{
+			Action:     constants.AuditUpdate,
+			Entity:     constants.AuditFieldSchedule,
+			EntityUUID: fieldResult.UUID,
+			Before:     &before,
+			After:      fieldResult,
+		})
+		return tx.webhook.Publish(ctx, constants.WebhookScheduleRescheduled, tx.toEventData(fieldResult, &fieldResult.Time))
 	})
 	if err != nil {
 		return nil, err
@@ -292,7 +485,7 @@ func (f *FieldScheduleService) Update(ctx context.Context, uuid string, request
 		Date:         fieldResult.Date.Format(time.DateOnly),
 		PricePerHour: fieldResult.Field.PricePerHour,
 		Status:       fieldResult.Status.GetStatusString(),
-		Time:         fmt.Sprintf("%s - %s", scheduleTime.StartTime, scheduleTime.EndTime),
+		Time:         fmt.Sprintf("%s - %s", fieldResult.Time.StartTime, fieldResult.Time.EndTime),
 		CreatedAt:    fieldResult.CreatedAt,
 		UpdatedAt:    fieldResult.UpdatedAt,
 	}
@@ -303,23 +496,80 @@ func (f *FieldScheduleService) Update(ctx context.Context, uuid string, request
 }
 
 func (f *FieldScheduleService) UpdateStatus(ctx context.Context, request *dto.UpdateStatusFieldScheduleRequest) error {
-	for _, item := range request.FieldScheduleIDs {
-		_, err := f.repositories.GetFieldScheduleRepository().FindByUUID(ctx, item)
-		if err != nil {
-			return err
-		}
+	return f.SetStatus(ctx, constants.Booked, request.OrderUUID, request.FieldScheduleIDs)
+}
 
-		err = f.repositories.GetFieldScheduleRepository().UpdateStatus(ctx, constants.Booked, item)
-		if err != nil {
-			return err
+// SetStatus moves the given schedules to status. Schedules already in that
+// status are left untouched so repeated calls do not emit duplicate events.
+// A booking records orderUUID on the slot; a release with an orderUUID only
+// frees the slots that order holds, so a late cancel cannot free a slot that
+// has since been booked by another order.
+func (f *FieldScheduleService) SetStatus(ctx context.Context, status constants.FieldScheduleStatus, orderUUID *uuid.UUID, fieldScheduleIDs []string) error {
+	eventType := constants.WebhookScheduleBooked
+	if status == constants.Available {
+		eventType = constants.WebhookScheduleReleased
+	}
+
+	var changed int
+	err := f.inTransaction(ctx, func(tx *FieldScheduleService) error {
+		for _, item := range fieldScheduleIDs {
+			fieldSchedule, err := tx.repositories.GetFieldScheduleRepository().FindByUUID(ctx, item)
+			if err != nil {
+				return err
+			}
+
+			if fieldSchedule.Status == status {
+				continue
+			}
+
+			if status == constants.Available && orderUUID != nil &&
+				(fieldSchedule.OrderUUID == nil || *fieldSchedule.OrderUUID != *orderUUID) {
+				logger.FromContext(ctx).Warnf("skip release of field schedule %s: not held by order %s", item, orderUUID)
+				continue
+			}
+
+			err = tx.repositories.GetFieldScheduleRepository().UpdateStatus(ctx, status, orderUUID, item)
+			if err != nil {
+				return err
+			}
+
+			after := *fieldSchedule
+			after.Status = status
+			after.OrderUUID = orderUUID
+			if status == constants.Available {
+				after.OrderUUID = nil
+			}
+			tx.auditLog.Record(ctx, dto.AuditLogRecord{
+				Action:     constants.AuditUpdateStatus,
+				Entity:     constants.AuditFieldSchedule,
+				EntityUUID: fieldSchedule.UUID,
+				Before:     fieldSchedule,
+				After:      &after,
+			})
+			err = tx.webhook.Publish(ctx, eventType, tx.toEventData(&after, &after.Time))
+			if err != nil {
+				return err
+			}
+			changed++
 		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if status == constants.Booked {
+		metrics.FieldSchedulesBookedTotal.Add(float64(changed))
+	} else {
+		metrics.FieldSchedulesReleasedTotal.Add(float64(changed))
 	}
 
 	return nil
 }
 
 func (f *FieldScheduleService) Delete(ctx context.Context, uuid string) error {
-	_, err := f.repositories.GetFieldScheduleRepository().FindByUUID(ctx, uuid)
+	fieldSchedule, err := f.repositories.GetFieldScheduleRepository().FindByUUID(ctx, uuid)
 	if err != nil {
 		return err
 	}
@@ -329,5 +579,104 @@ func (f *FieldScheduleService) Delete(ctx context.Context, uuid string) error {
 		return err
 	}
 
+	f.auditLog.Record(ctx, dto.AuditLogRecord{
+		Action:     constants.AuditDelete,
+		Entity:     constants.AuditFieldSchedule,
+		EntityUUID: fieldSchedule.UUID,
+		Before:     fieldSchedule,
+	})
+
 	return nil
 }
+
+func (f *FieldScheduleService) GetTrash(ctx context.Context, param *dto.TrashRequestParam) (*util.PaginationResult, error) {
+	fieldSchedules, total, err := f.repositories.GetFieldScheduleRepository().FindAllTrashed(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldSchedulesResults := make([]dto.FieldScheduleReponse, 0, len(fieldSchedules))
+	for _, schedule := range fieldSchedules {
+		fieldSchedulesResults = append(fieldSchedulesResults, dto.FieldScheduleReponse{
+			UUID:         schedule.UUID,
+			FieldName:    schedule.Field.Name,
+			Date:         schedule.Date.Format(time.DateOnly),
+			PricePerHour: schedule.Field.PricePerHour,
+			Status:       schedule.Status.GetStatusString(),
+			Time:         fmt.Sprintf("%s - %s", schedule.Time.StartTime, schedule.Time.EndTime),
+			CreatedAt:    schedule.CreatedAt,
+			UpdatedAt:    schedule.UpdatedAt,
+			DeletedAt:    util.DeletedAtTime(schedule.DeletedAt),
+		})
+	}
+
+	pagination := &util.PaginationParam{
+		Page:  param.Page,
+		Limit: param.Limit,
+		Count: total,
+		Data:  fieldSchedulesResults,
+	}
+
+	response := util.GeneratePagination(*pagination)
+
+	return &response, nil
+}
+
+// Restore brings a trashed schedule back. Its field and time must still be live,
+// and the slot must not have been taken by a schedule created since.
+func (f *FieldScheduleService) Restore(ctx context.Context, uuid string) (*dto.FieldScheduleReponse, error) {
+	fieldSchedule, err := f.repositories.GetFieldScheduleRepository().FindTrashedByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if util.DeletedAtTime(fieldSchedule.Field.DeletedAt) != nil {
+		return nil, errField.ErrFieldNotFound
+	}
+
+	if util.DeletedAtTime(fieldSchedule.Time.DeletedAt) != nil {
+		return nil, errTime.ErrTimeNotFound
+	}
+
+	existing, err := f.repositories.GetFieldScheduleRepository().FindByDateAndTimeID(
+		ctx,
+		fieldSchedule.Date.Format(time.DateOnly),
+		int(fieldSchedule.TimeID),
+		int(fieldSchedule.FieldID),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing != nil {
+		return nil, errFieldSchedule.ErrFieldShceduleExist
+	}
+
+	err = f.repositories.GetFieldScheduleRepository().Restore(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	before := *fieldSchedule
+	fieldSchedule.DeletedAt = nil
+	f.auditLog.Record(ctx, dto.AuditLogRecord{
+		Action:     constants.AuditRestore,
+		Entity:     constants.AuditFieldSchedule,
+		EntityUUID: fieldSchedule.UUID,
+		Before:     &before,
+		After:      fieldSchedule,
+	})
+
+	response := dto.FieldScheduleReponse{
+		UUID:         fieldSchedule.UUID,
+		FieldName:    fieldSchedule.Field.Name,
+		Date:         fieldSchedule.Date.Format(time.DateOnly),
+		PricePerHour: fieldSchedule.Field.PricePerHour,
+		Status:       fieldSchedule.Status.GetStatusString(),
+		Time:         fmt.Sprintf("%s - %s", fieldSchedule.Time.StartTime, fieldSchedule.Time.EndTime),
+		CreatedAt:    fieldSchedule.CreatedAt,
+		UpdatedAt:    fieldSchedule.UpdatedAt,
+	}
+
+	return &response, nil
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"field-service/common/broker"
+	"field-service/common/logger"
+	"field-service/domain/models"
+	"field-service/repositories"
+	"time"
+)
+
+const (
+	defaultPollInterval = time.Second
+	defaultBatchSize    = 100
+	defaultMaxAttempts  = 10
+	// claimLease covers publishing a whole batch. An event still being sent when
+	// it runs out may be sent twice; the broker drops it by message ID.
+	claimLease = 5 * time.Minute
+)
+
+type RelayConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	MaxAttempts  int
+}
+
+type Relay struct {
+	repositories repositories.IRepostitoryRegistry
+	publisher    broker.IPublisher
+	config       RelayConfig
+}
+
+type IRelay interface {
+	Run(context.Context)
+}
+
+func NewRelay(repositories repositories.IRepostitoryRegistry, publisher broker.IPublisher, config RelayConfig) IRelay {
+	if config.PollInterval <= 0 {
+		config.PollInterval = defaultPollInterval
+	}
+
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaultBatchSize
+	}
+
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = defaultMaxAttempts
+	}
+
+	return &Relay{
+		repositories: repositories,
+		publisher:    publisher,
+		config:       config,
+	}
+}
+
+// Run publishes pending outbox events until the context is cancelled. A full
+// batch is followed immediately by the next one so a backlog drains quickly.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.config.PollInterval)
+	defer ticker.Stop()
+
+	// A batch in flight is committed even when shutdown starts.
+	workCtx := context.WithoutCancel(ctx)
+	for {
+		processed, err := r.repositories.GetOutboxRepository().ProcessPending(workCtx, r.config.BatchSize, r.config.MaxAttempts, claimLease, func(event *models.OutboxEvent) error {
+			return r.publish(workCtx, event)
+		})
+		if err != nil {
+			logger.FromContext(ctx).Errorf("failed to relay outbox events: %v", err)
+		}
+
+		if err == nil && processed >= r.config.BatchSize && ctx.Err() == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Relay) publish(ctx context.Context, event *models.OutboxEvent) error {
+	err := r.publisher.Publish(ctx, broker.Message{
+		ID:      event.UUID.String(),
+		Topic:   string(event.EventType),
+		Key:     event.AggregateUUID.String(),
+		Payload: event.Payload,
+		Headers: map[string]string{
+			"aggregate-type": event.AggregateType,
+		},
+	})
+	if err != nil {
+		logger.FromContext(ctx).Warnf("failed to publish outbox event %s (attempt %d): %v", event.UUID, event.Attempts+1, err)
+	}
+
+	return err
+}
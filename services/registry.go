@@ -3,9 +3,11 @@ package services
 import (
 	gcs "field-service/common/gcs"
 	"field-service/repositories"
+	auditLogService "field-service/services/auditlog"
 	fieldService "field-service/services/field"
 	fieldScheduleService "field-service/services/fieldschedule"
 	timeService "field-service/services/time"
+	webhookService "field-service/services/webhook"
 )
 
 type ServiceRegistry struct {
@@ -17,6 +19,8 @@ type IServiceRegistry interface {
 	GetField() fieldService.IFieldService
 	GetFieldSchedule() fieldScheduleService.IFieldScheduleService
 	GetTime() timeService.ITimeService
+	GetAuditLog() auditLogService.IAuditLogService
+	GetWebhook() webhookService.IWebhookService
 }
 
 func NewServiceRegistry(repositories repositories.IRepostitoryRegistry, gcs gcs.IGCSClient) IServiceRegistry {
@@ -27,13 +31,21 @@ func NewServiceRegistry(repositories repositories.IRepostitoryRegistry, gcs gcs.
 }
 
 func (s *ServiceRegistry) GetField() fieldService.IFieldService {
-	return fieldService.NewFieldService(s.repositories, s.gcs)
+	return fieldService.NewFieldService(s.repositories, s.gcs, s.GetAuditLog(), s.GetWebhook())
 }
 
 func (s *ServiceRegistry) GetFieldSchedule() fieldScheduleService.IFieldScheduleService {
-	return fieldScheduleService.NewFieldScheduleService(s.repositories)
+	return fieldScheduleService.NewFieldScheduleService(s.repositories, s.GetAuditLog(), s.GetWebhook())
 }
 
 func (s *ServiceRegistry) GetTime() timeService.ITimeService {
-	return timeService.NewTimeService(s.repositories)
+	return timeService.NewTimeService(s.repositories, s.GetAuditLog())
+}
+
+func (s *ServiceRegistry) GetAuditLog() auditLogService.IAuditLogService {
+	return auditLogService.NewAuditLogService(s.repositories)
+}
+
+func (s *ServiceRegistry) GetWebhook() webhookService.IWebhookService {
+	return webhookService.NewWebhookService(s.repositories)
 }
@@ -2,26 +2,35 @@ package services
 
 import (
 	"context"
+	"field-service/common/util"
+	"field-service/constants"
+	errTime "field-service/constants/error/time"
 	"field-service/domain/dto"
 	"field-service/domain/models"
 	"field-service/repositories"
+	auditLogService "field-service/services/auditlog"
 
 	"github.com/google/uuid"
 )
 
 type TimeService struct {
 	repositories repositories.IRepostitoryRegistry
+	auditLog     auditLogService.IAuditLogService
 }
 
 type ITimeService interface {
 	GetAll(context.Context) ([]dto.TimeResponse, error)
 	GetByUUID(context.Context, string) (*dto.TimeResponse, error)
 	Create(context.Context, *dto.TimeRequest) (*dto.TimeResponse, error)
+	Delete(context.Context, string) error
+	GetTrash(context.Context, *dto.TrashRequestParam) (*util.PaginationResult, error)
+	Restore(context.Context, string) (*dto.TimeResponse, error)
 }
 
-func NewTimeService(repositories repositories.IRepostitoryRegistry) ITimeService {
+func NewTimeService(repositories repositories.IRepostitoryRegistry, auditLog auditLogService.IAuditLogService) ITimeService {
 	return &TimeService{
 		repositories: repositories,
+		auditLog:     auditLog,
 	}
 }
 
@@ -73,6 +82,106 @@ func (t *TimeService) Create(ctx context.Context, request *dto.TimeRequest) (*dt
 		return nil, err
 	}
 
+	t.auditLog.Record(ctx, dto.AuditLogRecord{
+		Action:     constants.AuditCreate,
+		Entity:     constants.AuditTime,
+		EntityUUID: time.UUID,
+		After:      time,
+	})
+
+	response := dto.TimeResponse{
+		UUID:      time.UUID,
+		StartTime: time.StartTime,
+		EndTime:   time.EndTime,
+		CreatedAt: time.CreatedAt,
+		UpdatedAt: time.UpdatedAt,
+	}
+
+	return &response, nil
+}
+
+func (t *TimeService) Delete(ctx context.Context, uuid string) error {
+	time, err := t.repositories.GetTimeRepository().FindByUUID(ctx, uuid)
+	if err != nil {
+		return err
+	}
+
+	err = t.repositories.GetTimeRepository().Delete(ctx, uuid)
+	if err != nil {
+		return err
+	}
+
+	t.auditLog.Record(ctx, dto.AuditLogRecord{
+		Action:     constants.AuditDelete,
+		Entity:     constants.AuditTime,
+		EntityUUID: time.UUID,
+		Before:     time,
+	})
+
+	return nil
+}
+
+func (t *TimeService) GetTrash(ctx context.Context, param *dto.TrashRequestParam) (*util.PaginationResult, error) {
+	times, total, err := t.repositories.GetTimeRepository().FindAllTrashed(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+
+	timeResults := make([]dto.TimeResponse, 0, len(times))
+	for _, time := range times {
+		timeResults = append(timeResults, dto.TimeResponse{
+			UUID:      time.UUID,
+			StartTime: time.StartTime,
+			EndTime:   time.EndTime,
+			CreatedAt: time.CreatedAt,
+			UpdatedAt: time.UpdatedAt,
+			DeletedAt: util.DeletedAtTime(time.DeletedAt),
+		})
+	}
+
+	pagination := &util.PaginationParam{
+		Page:  param.Page,
+		Count: total,
+		Limit: param.Limit,
+		Data:  timeResults,
+	}
+
+	response := util.GeneratePagination(*pagination)
+
+	return &response, nil
+}
+
+// Restore brings a trashed time slot back unless an identical slot was created since.
+func (t *TimeService) Restore(ctx context.Context, uuid string) (*dto.TimeResponse, error) {
+	time, err := t.repositories.GetTimeRepository().FindTrashedByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := t.repositories.GetTimeRepository().FindByStartTimeAndEndTime(ctx, time.StartTime, time.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing != nil {
+		return nil, errTime.ErrTimeExist
+	}
+
+	err = t.repositories.GetTimeRepository().Restore(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	before := *time
+	time.DeletedAt = nil
+	t.auditLog.Record(ctx, dto.AuditLogRecord{
+		Action:     constants.AuditRestore,
+		Entity:     constants.AuditTime,
+		EntityUUID: time.UUID,
+		Before:     &before,
+		After:      time,
+	})
+
 	response := dto.TimeResponse{
 		UUID:      time.UUID,
 		StartTime: time.StartTime,
@@ -0,0 +1,188 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"field-service/common/logger"
+	"field-service/constants"
+	"field-service/domain/models"
+	"field-service/repositories"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultMaxAttempts  = 8
+	defaultRetryBase    = 10 * time.Second
+	defaultPollInterval = 5 * time.Second
+	defaultTimeout      = 10 * time.Second
+	defaultBatchSize    = 50
+	maxRetryDelay       = 6 * time.Hour
+)
+
+type DispatcherConfig struct {
+	MaxAttempts  int
+	RetryBase    time.Duration
+	PollInterval time.Duration
+	Timeout      time.Duration
+	BatchSize    int
+}
+
+type Dispatcher struct {
+	repositories repositories.IRepostitoryRegistry
+	httpClient   *http.Client
+	config       DispatcherConfig
+}
+
+type IDispatcher interface {
+	Run(context.Context)
+}
+
+func NewDispatcher(repositories repositories.IRepostitoryRegistry, config DispatcherConfig) IDispatcher {
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = defaultMaxAttempts
+	}
+
+	if config.RetryBase <= 0 {
+		config.RetryBase = defaultRetryBase
+	}
+
+	if config.PollInterval <= 0 {
+		config.PollInterval = defaultPollInterval
+	}
+
+	if config.Timeout <= 0 {
+		config.Timeout = defaultTimeout
+	}
+
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaultBatchSize
+	}
+
+	return &Dispatcher{
+		repositories: repositories,
+		httpClient:   &http.Client{Timeout: config.Timeout},
+		config:       config,
+	}
+}
+
+// Run polls for due deliveries until the context is cancelled.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		d.dispatchDue(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *Dispatcher) dispatchDue(ctx context.Context) {
+	// A send in flight is finished even when shutdown starts.
+	workCtx := context.WithoutCancel(ctx)
+
+	// Deliveries are claimed one at a time so each lease only has to outlive a
+	// single send; another replica never picks a row up while it is in flight.
+	for i := 0; i < d.config.BatchSize; i++ {
+		if ctx.Err() != nil {
+			return
+		}
+
+		deliveries, err := d.repositories.GetWebhookRepository().ClaimDueDeliveries(workCtx, 1, 2*d.config.Timeout)
+		if err != nil {
+			logger.FromContext(ctx).Errorf("failed to claim webhook deliveries: %v", err)
+			return
+		}
+
+		if len(deliveries) == 0 {
+			return
+		}
+
+		d.deliver(workCtx, &deliveries[0])
+	}
+}
+
+func (d *Dispatcher) sign(secret string, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return fmt.Sprintf("sha256=%s", hex.EncodeToString(mac.Sum(nil)))
+}
+
+func (d *Dispatcher) send(ctx context.Context, delivery *models.WebhookDelivery) (int, error) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Subscription.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(constants.XWebhookID, delivery.EventID.String())
+	request.Header.Set(constants.XWebhookEvent, string(delivery.EventType))
+	request.Header.Set(constants.XWebhookTimestamp, timestamp)
+	request.Header.Set(constants.XWebhookSignature, d.sign(delivery.Subscription.Secret, timestamp, delivery.Payload))
+
+	response, err := d.httpClient.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 1<<16))
+
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return response.StatusCode, fmt.Errorf("unexpected status %d", response.StatusCode)
+	}
+
+	return response.StatusCode, nil
+}
+
+func (d *Dispatcher) retryDelay(attempts int) time.Duration {
+	delay := d.config.RetryBase << (attempts - 1)
+	if delay <= 0 || delay > maxRetryDelay {
+		return maxRetryDelay
+	}
+
+	return delay
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, delivery *models.WebhookDelivery) {
+	delivery.Attempts++
+	if delivery.Subscription.ID == 0 || !delivery.Subscription.IsActive {
+		delivery.Status = constants.WebhookDead
+		delivery.LastError = "subscription is no longer active"
+	} else {
+		statusCode, err := d.send(ctx, delivery)
+		delivery.LastStatusCode = statusCode
+		if err == nil {
+			now := time.Now()
+			delivery.Status = constants.WebhookDelivered
+			delivery.DeliveredAt = &now
+			delivery.LastError = ""
+		} else {
+			delivery.LastError = err.Error()
+			if delivery.Attempts >= d.config.MaxAttempts {
+				delivery.Status = constants.WebhookDead
+				logger.FromContext(ctx).Warnf("webhook delivery %s moved to dead letter after %d attempts: %v", delivery.UUID, delivery.Attempts, err)
+			} else {
+				delivery.NextAttemptAt = time.Now().Add(d.retryDelay(delivery.Attempts))
+			}
+		}
+	}
+
+	err := d.repositories.GetWebhookRepository().UpdateDelivery(ctx, delivery)
+	if err != nil {
+		logger.FromContext(ctx).Errorf("failed to update webhook delivery %s: %v", delivery.UUID, err)
+	}
+}
@@ -0,0 +1,187 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"field-service/common/util"
+	"field-service/constants"
+	errWebhook "field-service/constants/error/webhook"
+	"field-service/domain/dto"
+	"field-service/domain/models"
+	"field-service/repositories"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WebhookService struct {
+	repositories repositories.IRepostitoryRegistry
+}
+
+type IWebhookService interface {
+	GetAllSubscriptions(context.Context) ([]dto.WebhookSubscriptionResponse, error)
+	CreateSubscription(context.Context, *dto.WebhookSubscriptionRequest) (*dto.WebhookSubscriptionResponse, error)
+	DeleteSubscription(context.Context, string) error
+	GetDeadLetters(context.Context, *dto.WebhookDeliveryRequestParam) (*util.PaginationResult, error)
+	Replay(context.Context, string) error
+	Publish(context.Context, constants.WebhookEventType, any) error
+}
+
+func NewWebhookService(repositories repositories.IRepostitoryRegistry) IWebhookService {
+	return &WebhookService{repositories: repositories}
+}
+
+func (w *WebhookService) toSubscriptionResponse(subscription *models.WebhookSubscription) dto.WebhookSubscriptionResponse {
+	return dto.WebhookSubscriptionResponse{
+		UUID:       subscription.UUID,
+		URL:        subscription.URL,
+		EventTypes: subscription.EventTypes,
+		IsActive:   subscription.IsActive,
+		CreatedAt:  subscription.CreatedAt,
+		UpdatedAt:  subscription.UpdatedAt,
+	}
+}
+
+func (w *WebhookService) GetAllSubscriptions(ctx context.Context) ([]dto.WebhookSubscriptionResponse, error) {
+	subscriptions, err := w.repositories.GetWebhookRepository().FindAllSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]dto.WebhookSubscriptionResponse, 0, len(subscriptions))
+	for i := range subscriptions {
+		results = append(results, w.toSubscriptionResponse(&subscriptions[i]))
+	}
+
+	return results, nil
+}
+
+func (w *WebhookService) CreateSubscription(ctx context.Context, request *dto.WebhookSubscriptionRequest) (*dto.WebhookSubscriptionResponse, error) {
+	for _, eventType := range request.EventTypes {
+		if !constants.WebhookEventType(eventType).IsValid() {
+			return nil, errWebhook.ErrInvalidWebhookEventType
+		}
+	}
+
+	subscription, err := w.repositories.GetWebhookRepository().CreateSubscription(ctx, &models.WebhookSubscription{
+		UUID:       uuid.New(),
+		URL:        request.URL,
+		EventTypes: request.EventTypes,
+		Secret:     request.Secret,
+		IsActive:   true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := w.toSubscriptionResponse(subscription)
+	return &response, nil
+}
+
+func (w *WebhookService) DeleteSubscription(ctx context.Context, uuid string) error {
+	_, err := w.repositories.GetWebhookRepository().FindSubscriptionByUUID(ctx, uuid)
+	if err != nil {
+		return err
+	}
+
+	return w.repositories.GetWebhookRepository().DeleteSubscription(ctx, uuid)
+}
+
+func (w *WebhookService) GetDeadLetters(ctx context.Context, param *dto.WebhookDeliveryRequestParam) (*util.PaginationResult, error) {
+	deliveries, total, err := w.repositories.GetWebhookRepository().FindDeadDeliveriesWithPagination(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]dto.WebhookDeliveryResponse, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		results = append(results, dto.WebhookDeliveryResponse{
+			UUID:             delivery.UUID,
+			SubscriptionUUID: delivery.Subscription.UUID,
+			URL:              delivery.Subscription.URL,
+			EventID:          delivery.EventID,
+			EventType:        delivery.EventType,
+			Payload:          json.RawMessage(delivery.Payload),
+			Status:           delivery.Status,
+			Attempts:         delivery.Attempts,
+			LastStatusCode:   delivery.LastStatusCode,
+			LastError:        delivery.LastError,
+			NextAttemptAt:    delivery.NextAttemptAt,
+			CreatedAt:        delivery.CreatedAt,
+			UpdatedAt:        delivery.UpdatedAt,
+		})
+	}
+
+	pagination := &util.PaginationParam{
+		Page:  param.Page,
+		Limit: param.Limit,
+		Count: total,
+		Data:  results,
+	}
+
+	response := util.GeneratePagination(*pagination)
+
+	return &response, nil
+}
+
+// Replay sends a dead delivery again from its first attempt. Pending deliveries
+// are left alone, since a dispatcher may hold a lease on them.
+func (w *WebhookService) Replay(ctx context.Context, uuid string) error {
+	delivery, err := w.repositories.GetWebhookRepository().FindDeliveryByUUID(ctx, uuid)
+	if err != nil {
+		return err
+	}
+
+	if delivery.Status != constants.WebhookDead {
+		return errWebhook.ErrWebhookDeliveryNotDead
+	}
+
+	delivery.Status = constants.WebhookPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = time.Now()
+	delivery.LastError = ""
+	delivery.LastStatusCode = 0
+
+	return w.repositories.GetWebhookRepository().UpdateDelivery(ctx, delivery)
+}
+
+// Publish queues the event for every matching subscription. Delivery happens
+// asynchronously in the dispatcher. Callers pass a service bound to the
+// transaction that makes the change, so the deliveries commit with it.
+func (w *WebhookService) Publish(ctx context.Context, eventType constants.WebhookEventType, data any) error {
+	subscriptions, err := w.repositories.GetWebhookRepository().FindActiveSubscriptionsByEventType(ctx, eventType)
+	if err != nil {
+		return err
+	}
+
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	event := dto.WebhookEvent{
+		ID:         uuid.New(),
+		Type:       eventType,
+		OccurredAt: time.Now(),
+		Data:       data,
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	deliveries := make([]models.WebhookDelivery, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		deliveries = append(deliveries, models.WebhookDelivery{
+			UUID:           uuid.New(),
+			SubscriptionID: subscription.ID,
+			EventID:        event.ID,
+			EventType:      eventType,
+			Payload:        models.JSON(payload),
+			Status:         constants.WebhookPending,
+			NextAttemptAt:  event.OccurredAt,
+		})
+	}
+
+	return w.repositories.GetWebhookRepository().CreateDeliveries(ctx, deliveries)
+}